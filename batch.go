@@ -0,0 +1,233 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// MaxBatchMessageIds is the number of message ids a batch call accepts.
+	MaxBatchMessageIds = 100
+
+	batchFallbackConcurrency = 8
+)
+
+// ErrBatchMessagesSkipped is set on the results of a batch whose messages
+// were partly skipped by the server, as the new message ids cannot be matched
+// to the original ones. Each of these messages may or may not have been
+// forwarded or copied, so they must not be retried as failed.
+var ErrBatchMessagesSkipped = errors.New("some messages of the batch were skipped, the message may have been sent")
+
+// ErrBatchMessageSkipped is set on the results of a batch whose messages were
+// all skipped by the server, e.g. because they were not found.
+var ErrBatchMessageSkipped = errors.New("message was skipped")
+
+// BatchResult is the result of a batch operation for one message.
+type BatchResult struct {
+	MessageId int
+	// NewMessageId is the id of the forwarded or copied message.
+	NewMessageId int
+	Err          error
+}
+
+// DeleteMessages deletes messages by batches of MaxBatchMessageIds, see
+// ForwardMessages. Messages that cannot be found are skipped by the server.
+func (c *BotClient) DeleteMessages(ctx context.Context, options DeleteMessagesOptions) ([]BatchResult, error) {
+	return c.batch(ctx, options.MessageIds,
+		func(ctx context.Context, ids []int) ([]MessageId, error) {
+			batchOptions := options
+			batchOptions.MessageIds = ids
+			return nil, c.postJson(ctx, apiDeleteMessages, batchOptions, nil)
+		},
+		func(ctx context.Context, id int) (int, error) {
+			return 0, c.DeleteMessage(ctx, DeleteMessageOptions{ChatId: options.ChatId, MessageId: Int(id)})
+		},
+	)
+}
+
+type DeleteMessagesOptions struct {
+	ChatId     *int  `json:"chat_id,omitempty"`
+	MessageIds []int `json:"message_ids,omitempty"`
+}
+
+// ForwardMessages forwards messages by batches of MaxBatchMessageIds, in the
+// order of their ids. If the server does not support batch calls, messages
+// are forwarded by concurrent single calls. A result is returned for every
+// distinct id, along with the first error of the results.
+func (c *BotClient) ForwardMessages(ctx context.Context, options ForwardMessagesOptions) ([]BatchResult, error) {
+	return c.batch(ctx, options.MessageIds,
+		func(ctx context.Context, ids []int) ([]MessageId, error) {
+			var messageIds []MessageId
+			batchOptions := options
+			batchOptions.MessageIds = ids
+			err := c.postJson(ctx, apiForwardMessages, batchOptions, &messageIds)
+			return messageIds, err
+		},
+		func(ctx context.Context, id int) (int, error) {
+			message, err := c.ForwardMessage(ctx, ForwardMessageOptions{
+				ChatId:              options.ChatId,
+				MessageThreadId:     options.MessageThreadId,
+				FromChatId:          options.FromChatId,
+				DisableNotification: options.DisableNotification,
+				ProtectContent:      options.ProtectContent,
+				MessageId:           Int(id),
+			})
+			return message.MessageId, err
+		},
+	)
+}
+
+type ForwardMessagesOptions struct {
+	ChatId              *int  `json:"chat_id,omitempty"`
+	MessageThreadId     *int  `json:"message_thread_id,omitempty"`
+	FromChatId          *int  `json:"from_chat_id,omitempty"`
+	MessageIds          []int `json:"message_ids,omitempty"`
+	DisableNotification *bool `json:"disable_notification,omitempty"`
+	ProtectContent      *bool `json:"protect_content,omitempty"`
+}
+
+// CopyMessages copies messages by batches of MaxBatchMessageIds, see
+// ForwardMessages.
+func (c *BotClient) CopyMessages(ctx context.Context, options CopyMessagesOptions) ([]BatchResult, error) {
+	return c.batch(ctx, options.MessageIds,
+		func(ctx context.Context, ids []int) ([]MessageId, error) {
+			var messageIds []MessageId
+			batchOptions := options
+			batchOptions.MessageIds = ids
+			err := c.postJson(ctx, apiCopyMessages, batchOptions, &messageIds)
+			return messageIds, err
+		},
+		func(ctx context.Context, id int) (int, error) {
+			copyOptions := CopyMessageOptions{
+				ChatId:              options.ChatId,
+				MessageThreadId:     options.MessageThreadId,
+				FromChatId:          options.FromChatId,
+				DisableNotification: options.DisableNotification,
+				ProtectContent:      options.ProtectContent,
+				MessageId:           Int(id),
+			}
+			if options.RemoveCaption != nil && *options.RemoveCaption {
+				copyOptions.Caption = String("")
+			}
+			message, err := c.CopyMessage(ctx, copyOptions)
+			return message.MessageId, err
+		},
+	)
+}
+
+type CopyMessagesOptions struct {
+	ChatId              *int  `json:"chat_id,omitempty"`
+	MessageThreadId     *int  `json:"message_thread_id,omitempty"`
+	FromChatId          *int  `json:"from_chat_id,omitempty"`
+	MessageIds          []int `json:"message_ids,omitempty"`
+	DisableNotification *bool `json:"disable_notification,omitempty"`
+	ProtectContent      *bool `json:"protect_content,omitempty"`
+	RemoveCaption       *bool `json:"remove_caption,omitempty"`
+}
+
+type batchFunc func(ctx context.Context, ids []int) ([]MessageId, error)
+
+type batchSingleFunc func(ctx context.Context, id int) (int, error)
+
+// batch calls callBatch with the sorted distinct ids, chunked. Once the server
+// does not know the batch method, the remaining ids go through callSingle.
+func (c *BotClient) batch(ctx context.Context, ids []int, callBatch batchFunc, callSingle batchSingleFunc) ([]BatchResult, error) {
+	ids = sortedUniqueIds(ids)
+	results := make([]BatchResult, len(ids))
+	for i, id := range ids {
+		results[i].MessageId = id
+	}
+
+	fallback := false
+	for start := 0; start < len(ids); start += MaxBatchMessageIds {
+		end := start + MaxBatchMessageIds
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := results[start:end]
+
+		if !fallback {
+			newIds, err := callBatch(ctx, ids[start:end])
+			if !isMethodNotFound(err) {
+				setBatchResults(chunk, newIds, err)
+				continue
+			}
+			fallback = true
+		}
+		batchSingle(ctx, chunk, callSingle)
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			return results, result.Err
+		}
+	}
+	return results, nil
+}
+
+func setBatchResults(chunk []BatchResult, newIds []MessageId, err error) {
+	switch {
+	case err != nil:
+		for i := range chunk {
+			chunk[i].Err = err
+		}
+	case newIds == nil:
+	case len(newIds) == len(chunk):
+		for i := range chunk {
+			chunk[i].NewMessageId = newIds[i].MessageId
+		}
+	case len(newIds) == 0:
+		for i := range chunk {
+			chunk[i].Err = ErrBatchMessageSkipped
+		}
+	default:
+		for i := range chunk {
+			chunk[i].Err = ErrBatchMessagesSkipped
+		}
+	}
+}
+
+func batchSingle(ctx context.Context, chunk []BatchResult, callSingle batchSingleFunc) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchFallbackConcurrency)
+	for i := range chunk {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(result *BatchResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.NewMessageId, result.Err = callSingle(ctx, result.MessageId)
+		}(&chunk[i])
+	}
+	wg.Wait()
+}
+
+func sortedUniqueIds(ids []int) []int {
+	sorted := make([]int, len(ids))
+	copy(sorted, ids)
+	sort.Ints(sorted)
+
+	unique := sorted[:0]
+	for _, id := range sorted {
+		if len(unique) == 0 || id != unique[len(unique)-1] {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// isMethodNotFound reports whether err was returned for a method the server
+// does not know, such as an older Bot API server. Servers word the description
+// differently, e.g. "Not Found" or "Not Found: method not found".
+func isMethodNotFound(err error) bool {
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.OriginalResponse == nil || apiErr.OriginalResponse.ErrorCode != 404 {
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(apiErr.OriginalResponse.Description), "not found")
+}
@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestBotClient_ForwardMessages(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	var batches [][]int
+	mux.HandleFunc("/forwardMessages", func(w http.ResponseWriter, r *http.Request) {
+		v := new(ForwardMessagesOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+		batches = append(batches, v.MessageIds)
+
+		newIds := make([]MessageId, len(v.MessageIds))
+		for i, id := range v.MessageIds {
+			newIds[i].MessageId = id + 1000
+		}
+		result, _ := json.Marshal(newIds)
+		fmt.Fprintf(w, `{"ok": true, "result": %s}`, result)
+	})
+
+	ids := make([]int, 150)
+	for i := range ids {
+		ids[i] = 150 - i
+	}
+	ids = append(ids, 1)
+
+	results, err := b.ForwardMessages(context.Background(), ForwardMessagesOptions{ChatId: Int(1), FromChatId: Int(2), MessageIds: ids})
+	if err != nil {
+		t.Fatalf("ForwardMessages returned error %v", err)
+	}
+	if len(batches) != 2 || len(batches[0]) != 100 || len(batches[1]) != 50 || batches[0][0] != 1 {
+		t.Errorf("ForwardMessages sent batches of %d and %d ids; want 100 and 50 sorted ids", len(batches[0]), len(batches[1]))
+	}
+	if len(results) != 150 || results[149] != (BatchResult{MessageId: 150, NewMessageId: 1150}) {
+		t.Errorf("ForwardMessages returned %d results ending with %+v; want 150 ending with message 150", len(results), results[len(results)-1])
+	}
+}
+
+func TestBotClient_DeleteMessagesFallback(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/deleteMessages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error_code": 404, "description": "Not Found: method not found"}`)
+	})
+
+	var mu sync.Mutex
+	var deleted []int
+	mux.HandleFunc("/deleteMessage", func(w http.ResponseWriter, r *http.Request) {
+		v := new(DeleteMessageOptions)
+		testBody(t, r, v)
+		if *v.MessageId == 2 {
+			fmt.Fprint(w, `{"ok": false, "error_code": 400, "description": "Bad Request: message to delete not found"}`)
+			return
+		}
+
+		mu.Lock()
+		deleted = append(deleted, *v.MessageId)
+		mu.Unlock()
+		fmt.Fprint(w, `{"ok": true, "result": true}`)
+	})
+
+	results, err := b.DeleteMessages(context.Background(), DeleteMessagesOptions{ChatId: Int(1), MessageIds: []int{3, 1, 2}})
+	if err == nil {
+		t.Errorf("DeleteMessages err is nil; want error of message 2")
+	}
+	if len(deleted) != 2 {
+		t.Errorf("DeleteMessages deleted %v; want 2 messages", deleted)
+	}
+
+	var failed []int
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.MessageId)
+		}
+	}
+	if want := []int{2}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("DeleteMessages failed for %v; want %v", failed, want)
+	}
+}
+
+func TestBotClient_CopyMessagesFallback(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/copyMessages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error_code": 404, "description": "Not Found"}`)
+	})
+
+	var mu sync.Mutex
+	var copied []*CopyMessageOptions
+	mux.HandleFunc("/copyMessage", func(w http.ResponseWriter, r *http.Request) {
+		v := new(CopyMessageOptions)
+		testBody(t, r, v)
+
+		mu.Lock()
+		copied = append(copied, v)
+		mu.Unlock()
+		fmt.Fprintf(w, `{"ok": true, "result": {"message_id": %d}}`, *v.MessageId+1000)
+	})
+
+	_, err := b.CopyMessages(context.Background(), CopyMessagesOptions{
+		ChatId:         Int(1),
+		FromChatId:     Int(2),
+		MessageIds:     []int{1, 2},
+		ProtectContent: Bool(true),
+		RemoveCaption:  Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("CopyMessages returned error %v", err)
+	}
+	if len(copied) != 2 {
+		t.Fatalf("CopyMessages made %d single calls; want 2", len(copied))
+	}
+	for _, v := range copied {
+		if v.ProtectContent == nil || !*v.ProtectContent {
+			t.Errorf("copyMessage of message %d has protect_content %v; want true", *v.MessageId, v.ProtectContent)
+		}
+		if v.Caption == nil || *v.Caption != "" {
+			t.Errorf("copyMessage of message %d has caption %v; want empty", *v.MessageId, v.Caption)
+		}
+	}
+}
+
+func TestIsMethodNotFound(t *testing.T) {
+	apiErr := func(code int, description string) error {
+		return &ApiError{OriginalResponse: &ApiResponse{ErrorCode: code, Description: description}}
+	}
+
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{apiErr(404, "Not Found"), true},
+		{apiErr(404, "Not Found: method not found"), true},
+		{apiErr(404, "not found"), true},
+		{apiErr(400, "Bad Request: message to delete not found"), false},
+		{apiErr(404, "Bad Request"), false},
+		{errors.New("Not Found"), false},
+	}
+	for _, tt := range tests {
+		if got := isMethodNotFound(tt.err); got != tt.want {
+			t.Errorf("isMethodNotFound(%v) = %v; want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBotClient_CopyMessagesSkipped(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	var result string
+	mux.HandleFunc("/copyMessages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"ok": true, "result": %s}`, result)
+	})
+
+	tests := []struct {
+		result string
+		want   error
+	}{
+		{`[{"message_id": 11}, {"message_id": 13}]`, ErrBatchMessagesSkipped},
+		{`[]`, ErrBatchMessageSkipped},
+	}
+	for _, tt := range tests {
+		result = tt.result
+		results, err := b.CopyMessages(context.Background(), CopyMessagesOptions{ChatId: Int(1), FromChatId: Int(2), MessageIds: []int{1, 2, 3}})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("CopyMessages with result %s err is %v; want %v", tt.result, err, tt.want)
+		}
+		for _, r := range results {
+			if r.Err != tt.want || r.NewMessageId != 0 {
+				t.Errorf("CopyMessages with result %s returned %+v; want error %v", tt.result, r, tt.want)
+			}
+		}
+	}
+}
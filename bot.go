@@ -0,0 +1,149 @@
+package telegram
+
+import (
+	"context"
+)
+
+type BotName struct {
+	Name string `json:"name"`
+}
+
+type BotDescription struct {
+	Description string `json:"description"`
+}
+
+type BotShortDescription struct {
+	ShortDescription string `json:"short_description"`
+}
+
+// LanguageOptions selects the language of a localized bot setting. Without a
+// language code, the setting for users with no dedicated one is used.
+type LanguageOptions struct {
+	LanguageCode *string `json:"language_code,omitempty"`
+}
+
+func (c *BotClient) SetMyName(ctx context.Context, options SetMyNameOptions) error {
+	return c.postJson(ctx, apiSetMyName, options, nil)
+}
+
+type SetMyNameOptions struct {
+	Name         *string `json:"name,omitempty"`
+	LanguageCode *string `json:"language_code,omitempty"`
+}
+
+func (c *BotClient) GetMyName(ctx context.Context, options LanguageOptions) (*BotName, error) {
+	var name BotName
+	err := c.postJson(ctx, apiGetMyName, options, &name)
+	return &name, err
+}
+
+func (c *BotClient) SetMyDescription(ctx context.Context, options SetMyDescriptionOptions) error {
+	return c.postJson(ctx, apiSetMyDescription, options, nil)
+}
+
+type SetMyDescriptionOptions struct {
+	Description  *string `json:"description,omitempty"`
+	LanguageCode *string `json:"language_code,omitempty"`
+}
+
+func (c *BotClient) GetMyDescription(ctx context.Context, options LanguageOptions) (*BotDescription, error) {
+	var description BotDescription
+	err := c.postJson(ctx, apiGetMyDescription, options, &description)
+	return &description, err
+}
+
+func (c *BotClient) SetMyShortDescription(ctx context.Context, options SetMyShortDescriptionOptions) error {
+	return c.postJson(ctx, apiSetMyShortDescription, options, nil)
+}
+
+type SetMyShortDescriptionOptions struct {
+	ShortDescription *string `json:"short_description,omitempty"`
+	LanguageCode     *string `json:"language_code,omitempty"`
+}
+
+func (c *BotClient) GetMyShortDescription(ctx context.Context, options LanguageOptions) (*BotShortDescription, error) {
+	var shortDescription BotShortDescription
+	err := c.postJson(ctx, apiGetMyShortDescription, options, &shortDescription)
+	return &shortDescription, err
+}
+
+const (
+	MenuButtonTypeCommands = "commands"
+	MenuButtonTypeWebApp   = "web_app"
+	MenuButtonTypeDefault  = "default"
+)
+
+// MenuButton is the button of the bot in private chats, see the MenuButton
+// functions.
+type MenuButton struct {
+	Type   string      `json:"type"`
+	Text   string      `json:"text,omitempty"`
+	WebApp *WebAppInfo `json:"web_app,omitempty"`
+}
+
+// MenuButtonCommands opens the list of bot commands.
+func MenuButtonCommands() *MenuButton {
+	return &MenuButton{Type: MenuButtonTypeCommands}
+}
+
+// MenuButtonWebApp opens the Web App at url.
+func MenuButtonWebApp(text, url string) *MenuButton {
+	return &MenuButton{Type: MenuButtonTypeWebApp, Text: text, WebApp: &WebAppInfo{Url: url}}
+}
+
+// MenuButtonDefault resets the button to the default one.
+func MenuButtonDefault() *MenuButton {
+	return &MenuButton{Type: MenuButtonTypeDefault}
+}
+
+// SetChatMenuButton sets the menu button of a private chat, or the default
+// menu button if ChatId is not set.
+func (c *BotClient) SetChatMenuButton(ctx context.Context, options SetChatMenuButtonOptions) error {
+	return c.postJson(ctx, apiSetChatMenuButton, options, nil)
+}
+
+type SetChatMenuButtonOptions struct {
+	ChatId     *int        `json:"chat_id,omitempty"`
+	MenuButton *MenuButton `json:"menu_button,omitempty"`
+}
+
+func (c *BotClient) GetChatMenuButton(ctx context.Context, options ChatOptions) (*MenuButton, error) {
+	var menuButton MenuButton
+	err := c.postJson(ctx, apiGetChatMenuButton, options, &menuButton)
+	return &menuButton, err
+}
+
+type ChatAdministratorRights struct {
+	IsAnonymous         bool `json:"is_anonymous"`
+	CanManageChat       bool `json:"can_manage_chat"`
+	CanDeleteMessages   bool `json:"can_delete_messages"`
+	CanManageVideoChats bool `json:"can_manage_video_chats"`
+	CanRestrictMembers  bool `json:"can_restrict_members"`
+	CanPromoteMembers   bool `json:"can_promote_members"`
+	CanChangeInfo       bool `json:"can_change_info"`
+	CanInviteUsers      bool `json:"can_invite_users"`
+	CanPostMessages     bool `json:"can_post_messages"`
+	CanEditMessages     bool `json:"can_edit_messages"`
+	CanPinMessages      bool `json:"can_pin_messages"`
+}
+
+// SetMyDefaultAdministratorRights sets the rights suggested to users adding
+// the bot to groups, or to channels if ForChannels is true.
+func (c *BotClient) SetMyDefaultAdministratorRights(ctx context.Context, options SetMyDefaultAdministratorRightsOptions) error {
+	return c.postJson(ctx, apiSetMyDefaultAdministratorRights, options, nil)
+}
+
+type SetMyDefaultAdministratorRightsOptions struct {
+	Rights      *ChatAdministratorRights `json:"rights,omitempty"`
+	ForChannels *bool                    `json:"for_channels,omitempty"`
+}
+
+func (c *BotClient) GetMyDefaultAdministratorRights(ctx context.Context, options GetMyDefaultAdministratorRightsOptions) (*ChatAdministratorRights, error) {
+	var rights ChatAdministratorRights
+	err := c.postJson(ctx, apiGetMyDefaultAdministratorRights, options, &rights)
+	return &rights, err
+}
+
+type GetMyDefaultAdministratorRightsOptions struct {
+	ForChannels *bool `json:"for_channels,omitempty"`
+}
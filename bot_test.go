@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBotClient_SetChatMenuButton(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := SetChatMenuButtonOptions{ChatId: Int(7), MenuButton: MenuButtonWebApp("Shop", "https://example.com/shop")}
+
+	mux.HandleFunc("/setChatMenuButton", func(w http.ResponseWriter, r *http.Request) {
+		v := new(SetChatMenuButtonOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": true}`)
+	})
+
+	if err := b.SetChatMenuButton(context.Background(), opts); err != nil {
+		t.Errorf("SetChatMenuButton returned error %v", err)
+	}
+}
+
+func TestBotClient_GetMyDescription(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := LanguageOptions{LanguageCode: String("de")}
+
+	mux.HandleFunc("/getMyDescription", func(w http.ResponseWriter, r *http.Request) {
+		v := new(LanguageOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": {"description": "Ein Bot"}}`)
+	})
+
+	got, err := b.GetMyDescription(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("GetMyDescription returned error %v", err)
+	}
+	if want := (&BotDescription{Description: "Ein Bot"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMyDescription returned %+v; want %+v", got, want)
+	}
+}
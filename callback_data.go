@@ -0,0 +1,261 @@
+package telegram
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrCallbackDataInvalid = errors.New("callback data is invalid or has been tampered with")
+	ErrCallbackDataExpired = errors.New("callback data has expired")
+	ErrCallbackDataTooLong = errors.New("callback data is too long and no store is configured")
+)
+
+const (
+	callbackDataSignatureSize = 8
+	callbackDataKeySize       = 9
+	callbackDataStoredMarker  = "~"
+)
+
+// CallbackDataStore keeps payloads that do not fit into callback data.
+type CallbackDataStore interface {
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Get returns nil if the key is unknown or has expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// CallbackDataCodec encodes values into signed callback data of the form
+// prefix:payload.signature, so that data modified by the client is rejected.
+// Struct values are encoded as a list of their exported fields. Payloads
+// exceeding MaxCallbackDataLength are kept in the store and referenced by key.
+type CallbackDataCodec struct {
+	secret []byte
+	store  CallbackDataStore
+	ttl    time.Duration
+}
+
+// NewCallbackDataCodec creates a codec signing data with secret. The store is
+// optional, without it encoding payloads that are too long fails.
+func NewCallbackDataCodec(secret []byte, store CallbackDataStore, ttl time.Duration) (*CallbackDataCodec, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("secret cannot be empty")
+	}
+
+	return &CallbackDataCodec{
+		secret: secret,
+		store:  store,
+		ttl:    ttl,
+	}, nil
+}
+
+// Encode encodes v as callback data identified by prefix.
+func (c *CallbackDataCodec) Encode(ctx context.Context, prefix string, v interface{}) (string, error) {
+	if prefix == "" || strings.Contains(prefix, ":") {
+		return "", fmt.Errorf("invalid callback data prefix %q", prefix)
+	}
+
+	payload, err := marshalCallbackValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	data := prefix + ":" + body + "." + c.sign(prefix, body)
+	if len(data) <= MaxCallbackDataLength {
+		return data, nil
+	}
+
+	if c.store == nil {
+		return "", ErrCallbackDataTooLong
+	}
+
+	key := make([]byte, callbackDataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	body = callbackDataStoredMarker + base64.RawURLEncoding.EncodeToString(key)
+	if err := c.store.Put(ctx, body, payload, c.ttl); err != nil {
+		return "", err
+	}
+
+	data = prefix + ":" + body + "." + c.sign(prefix, body)
+	if len(data) > MaxCallbackDataLength {
+		return "", fmt.Errorf("callback data prefix %q is too long", prefix)
+	}
+	return data, nil
+}
+
+// Decode verifies data and decodes it into v, which must be a pointer.
+func (c *CallbackDataCodec) Decode(ctx context.Context, data string, v interface{}) error {
+	sep := strings.Index(data, ":")
+	dot := strings.LastIndex(data, ".")
+	if sep < 1 || dot < sep {
+		return ErrCallbackDataInvalid
+	}
+	prefix, body, signature := data[:sep], data[sep+1:dot], data[dot+1:]
+
+	if !hmac.Equal([]byte(signature), []byte(c.sign(prefix, body))) {
+		return ErrCallbackDataInvalid
+	}
+
+	var payload []byte
+	if strings.HasPrefix(body, callbackDataStoredMarker) {
+		if c.store == nil {
+			return ErrCallbackDataExpired
+		}
+		stored, err := c.store.Get(ctx, body)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return ErrCallbackDataExpired
+		}
+		payload = stored
+	} else {
+		decoded, err := base64.RawURLEncoding.DecodeString(body)
+		if err != nil {
+			return ErrCallbackDataInvalid
+		}
+		payload = decoded
+	}
+
+	return unmarshalCallbackValue(payload, v)
+}
+
+func (c *CallbackDataCodec) sign(prefix, body string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(prefix + ":" + body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:callbackDataSignatureSize])
+}
+
+// CallbackDataPrefix returns the prefix of callback data encoded by a CallbackDataCodec.
+func CallbackDataPrefix(data string) string {
+	if i := strings.Index(data, ":"); i > 0 {
+		return data[:i]
+	}
+	return ""
+}
+
+func marshalCallbackValue(v interface{}) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return json.Marshal(v)
+	}
+
+	var fields []interface{}
+	for i := 0; i < rv.NumField(); i++ {
+		if rv.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		fields = append(fields, rv.Field(i).Interface())
+	}
+	return json.Marshal(fields)
+}
+
+func unmarshalCallbackValue(payload []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("callback data must be decoded into a non-nil pointer")
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return json.Unmarshal(payload, v)
+	}
+
+	var fields []json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return ErrCallbackDataInvalid
+	}
+	for i, n := 0, 0; i < rv.NumField() && n < len(fields); i++ {
+		if rv.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		if err := json.Unmarshal(fields[n], rv.Field(i).Addr().Interface()); err != nil {
+			return ErrCallbackDataInvalid
+		}
+		n++
+	}
+	return nil
+}
+
+// MemoryCallbackDataStore is a CallbackDataStore keeping payloads in memory.
+type MemoryCallbackDataStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryCallbackDataEntry
+}
+
+type memoryCallbackDataEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemoryCallbackDataStore() *MemoryCallbackDataStore {
+	return &MemoryCallbackDataStore{
+		entries: make(map[string]memoryCallbackDataEntry),
+	}
+}
+
+func (s *MemoryCallbackDataStore) Put(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.entries {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	entry := memoryCallbackDataEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryCallbackDataStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+	return entry.value, nil
+}
+
+type CallbackDataHandlerFunc func(ctx context.Context, query *CallbackQuery, data interface{}) error
+
+// CallbackData routes callback queries encoded by codec under prefix. The data
+// is decoded into a new value of the type of prototype and passed to handler
+// as a pointer.
+func (r *Router) CallbackData(codec *CallbackDataCodec, prefix string, prototype interface{}, handler CallbackDataHandlerFunc) {
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	r.HandleFunc(CallbackQueryPrefixFilter(prefix+":"), func(ctx context.Context, update *Update) error {
+		data := reflect.New(t).Interface()
+		if err := codec.Decode(ctx, update.CallbackQuery.Data, data); err != nil {
+			return fmt.Errorf("decode callback data: %w", err)
+		}
+		return handler(ctx, update.CallbackQuery, data)
+	})
+}
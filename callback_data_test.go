@@ -0,0 +1,88 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testCallbackData struct {
+	Action string
+	Page   int
+	Tags   []string
+}
+
+func TestCallbackDataCodec(t *testing.T) {
+	codec, err := NewCallbackDataCodec([]byte("secret"), NewMemoryCallbackDataStore(), 0)
+	if err != nil {
+		t.Fatalf("NewCallbackDataCodec returned error %v", err)
+	}
+
+	tests := []testCallbackData{
+		{Action: "open", Page: 2},
+		{Action: "filter", Tags: []string{strings.Repeat("long", 10), strings.Repeat("tags", 10)}},
+	}
+	for _, want := range tests {
+		data, err := codec.Encode(context.Background(), "page", want)
+		if err != nil {
+			t.Fatalf("Encode returned error %v", err)
+		}
+		if len(data) > MaxCallbackDataLength {
+			t.Errorf("Encode returned %d bytes; want at most %d", len(data), MaxCallbackDataLength)
+		}
+
+		var got testCallbackData
+		if err := codec.Decode(context.Background(), data, &got); err != nil {
+			t.Fatalf("Decode returned error %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Decode returned %+v; want %+v", got, want)
+		}
+	}
+}
+
+func TestCallbackDataCodec_Tampered(t *testing.T) {
+	codec, _ := NewCallbackDataCodec([]byte("secret"), nil, 0)
+
+	data, err := codec.Encode(context.Background(), "page", testCallbackData{Action: "open", Page: 2})
+	if err != nil {
+		t.Fatalf("Encode returned error %v", err)
+	}
+
+	forged, _ := NewCallbackDataCodec([]byte("other"), nil, 0)
+	tampered, _ := forged.Encode(context.Background(), "page", testCallbackData{Action: "open", Page: 3})
+
+	for _, d := range []string{tampered, strings.Replace(data, "page:", "pager:", 1), data[:len(data)-1]} {
+		var v testCallbackData
+		if err := codec.Decode(context.Background(), d, &v); !errors.Is(err, ErrCallbackDataInvalid) {
+			t.Errorf("Decode(%q) err is %v; want %v", d, err, ErrCallbackDataInvalid)
+		}
+	}
+
+	long := testCallbackData{Action: strings.Repeat("x", 64)}
+	if _, err := codec.Encode(context.Background(), "page", long); !errors.Is(err, ErrCallbackDataTooLong) {
+		t.Errorf("Encode err is %v; want %v", err, ErrCallbackDataTooLong)
+	}
+}
+
+func TestRouter_CallbackData(t *testing.T) {
+	codec, _ := NewCallbackDataCodec([]byte("secret"), nil, 0)
+	data, _ := codec.Encode(context.Background(), "page", testCallbackData{Action: "open", Page: 2})
+
+	var got *testCallbackData
+	r := NewRouter()
+	r.CallbackData(codec, "page", testCallbackData{}, func(ctx context.Context, query *CallbackQuery, data interface{}) error {
+		got = data.(*testCallbackData)
+		return nil
+	})
+
+	update := &Update{CallbackQuery: &CallbackQuery{Data: data}}
+	if err := r.HandleUpdate(context.Background(), update); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+	if want := (&testCallbackData{Action: "open", Page: 2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("handler received %+v; want %+v", got, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 type Chat struct {
 	Id               int              `json:"id"`
 	Type             string           `json:"type"`
+	IsForum          bool             `json:"is_forum"`
 	Title            string           `json:"title"`
 	Username         string           `json:"username"`
 	FirstName        string           `json:"first_name"`
@@ -47,6 +48,97 @@ type ChatMember struct {
 	UntilDate             int    `json:"until_date"`
 }
 
+const (
+	ChatMemberStatusCreator       = "creator"
+	ChatMemberStatusAdministrator = "administrator"
+	ChatMemberStatusMember        = "member"
+	ChatMemberStatusRestricted    = "restricted"
+	ChatMemberStatusLeft          = "left"
+	ChatMemberStatusKicked        = "kicked"
+)
+
+// isPresent reports whether the member is in the chat.
+func (m *ChatMember) isPresent() bool {
+	switch m.status() {
+	case ChatMemberStatusCreator, ChatMemberStatusAdministrator, ChatMemberStatusMember:
+		return true
+	case ChatMemberStatusRestricted:
+		return m.IsMember
+	}
+	return false
+}
+
+func (m *ChatMember) isAdministrator() bool {
+	return m.status() == ChatMemberStatusCreator || m.status() == ChatMemberStatusAdministrator
+}
+
+func (m *ChatMember) status() string {
+	if m == nil {
+		return ""
+	}
+	return m.Status
+}
+
+// ChatMemberUpdated is a change of the status of a chat member.
+type ChatMemberUpdated struct {
+	Chat          *Chat           `json:"chat"`
+	From          *User           `json:"from"`
+	Date          int             `json:"date"`
+	OldChatMember *ChatMember     `json:"old_chat_member"`
+	NewChatMember *ChatMember     `json:"new_chat_member"`
+	InviteLink    *ChatInviteLink `json:"invite_link"`
+}
+
+// Joined reports whether the member was not in the chat and now is.
+func (u *ChatMemberUpdated) Joined() bool {
+	return !u.OldChatMember.isPresent() && u.NewChatMember.isPresent()
+}
+
+// Left reports whether the member was in the chat and now is not, whether they
+// left or were banned.
+func (u *ChatMemberUpdated) Left() bool {
+	return u.OldChatMember.isPresent() && !u.NewChatMember.isPresent()
+}
+
+// Promoted reports whether the member became an administrator.
+func (u *ChatMemberUpdated) Promoted() bool {
+	return !u.OldChatMember.isAdministrator() && u.NewChatMember.isAdministrator()
+}
+
+// Restricted reports whether the member became restricted.
+func (u *ChatMemberUpdated) Restricted() bool {
+	return u.OldChatMember.status() != ChatMemberStatusRestricted && u.NewChatMember.status() == ChatMemberStatusRestricted
+}
+
+// BotBlocked reports whether the user blocked the bot, for my_chat_member
+// updates of private chats.
+func (u *ChatMemberUpdated) BotBlocked() bool {
+	return u.Chat != nil && u.Chat.Type == "private" &&
+		u.OldChatMember.status() != ChatMemberStatusKicked && u.NewChatMember.status() == ChatMemberStatusKicked
+}
+
+type ChatInviteLink struct {
+	InviteLink              string `json:"invite_link"`
+	Creator                 *User  `json:"creator"`
+	CreatesJoinRequest      bool   `json:"creates_join_request"`
+	IsPrimary               bool   `json:"is_primary"`
+	IsRevoked               bool   `json:"is_revoked"`
+	Name                    string `json:"name"`
+	ExpireDate              int    `json:"expire_date"`
+	MemberLimit             int    `json:"member_limit"`
+	PendingJoinRequestCount int    `json:"pending_join_request_count"`
+}
+
+// ChatJoinRequest is a request to join a chat, sent to administrators when
+// the invite link used creates join requests.
+type ChatJoinRequest struct {
+	Chat       *Chat           `json:"chat"`
+	From       *User           `json:"from"`
+	Date       int             `json:"date"`
+	Bio        string          `json:"bio"`
+	InviteLink *ChatInviteLink `json:"invite_link"`
+}
+
 type ChatPermissions struct {
 	CanSendMessages       bool `json:"can_send_messages"`
 	CanSendMediaMessages  bool `json:"can_send_media_messages"`
@@ -79,8 +171,9 @@ func (c *BotClient) SendChatAction(ctx context.Context, options SendChatActionOp
 }
 
 type SendChatActionOptions struct {
-	ChatId *int    `json:"chat_id,omitempty"`
-	Action *string `json:"action,omitempty"`
+	ChatId          *int    `json:"chat_id,omitempty"`
+	MessageThreadId *int    `json:"message_thread_id,omitempty"`
+	Action          *string `json:"action,omitempty"`
 }
 
 func (c *BotClient) KickChatMember(ctx context.Context, options KickChatMemberOptions) error {
@@ -151,12 +244,67 @@ type SetChatPermissionsOptions struct {
 	Permissions *ChatPermissions `json:"permissions,omitempty"`
 }
 
+// ExportChatInviteLink generates a new primary invite link, revoking the
+// previous one. Use CreateChatInviteLink for additional links.
 func (c *BotClient) ExportChatInviteLink(ctx context.Context, options ChatOptions) (string, error) {
 	var inviteLink string
 	err := c.postJson(ctx, apiExportChatInviteLink, options, &inviteLink)
 	return inviteLink, err
 }
 
+func (c *BotClient) CreateChatInviteLink(ctx context.Context, options CreateChatInviteLinkOptions) (*ChatInviteLink, error) {
+	var inviteLink ChatInviteLink
+	err := c.postJson(ctx, apiCreateChatInviteLink, options, &inviteLink)
+	return &inviteLink, err
+}
+
+type CreateChatInviteLinkOptions struct {
+	ChatId             *int    `json:"chat_id,omitempty"`
+	Name               *string `json:"name,omitempty"`
+	ExpireDate         *int    `json:"expire_date,omitempty"`
+	MemberLimit        *int    `json:"member_limit,omitempty"`
+	CreatesJoinRequest *bool   `json:"creates_join_request,omitempty"`
+}
+
+func (c *BotClient) EditChatInviteLink(ctx context.Context, options EditChatInviteLinkOptions) (*ChatInviteLink, error) {
+	var inviteLink ChatInviteLink
+	err := c.postJson(ctx, apiEditChatInviteLink, options, &inviteLink)
+	return &inviteLink, err
+}
+
+type EditChatInviteLinkOptions struct {
+	ChatId             *int    `json:"chat_id,omitempty"`
+	InviteLink         *string `json:"invite_link,omitempty"`
+	Name               *string `json:"name,omitempty"`
+	ExpireDate         *int    `json:"expire_date,omitempty"`
+	MemberLimit        *int    `json:"member_limit,omitempty"`
+	CreatesJoinRequest *bool   `json:"creates_join_request,omitempty"`
+}
+
+func (c *BotClient) RevokeChatInviteLink(ctx context.Context, options RevokeChatInviteLinkOptions) (*ChatInviteLink, error) {
+	var inviteLink ChatInviteLink
+	err := c.postJson(ctx, apiRevokeChatInviteLink, options, &inviteLink)
+	return &inviteLink, err
+}
+
+type RevokeChatInviteLinkOptions struct {
+	ChatId     *int    `json:"chat_id,omitempty"`
+	InviteLink *string `json:"invite_link,omitempty"`
+}
+
+func (c *BotClient) ApproveChatJoinRequest(ctx context.Context, options ChatJoinRequestOptions) error {
+	return c.postJson(ctx, apiApproveChatJoinRequest, options, nil)
+}
+
+func (c *BotClient) DeclineChatJoinRequest(ctx context.Context, options ChatJoinRequestOptions) error {
+	return c.postJson(ctx, apiDeclineChatJoinRequest, options, nil)
+}
+
+type ChatJoinRequestOptions struct {
+	ChatId *int `json:"chat_id,omitempty"`
+	UserId *int `json:"user_id,omitempty"`
+}
+
 func (c *BotClient) SetChatPhoto(ctx context.Context, options SetChatPhotoOptions, photo *InputFile) error {
 	if photo != nil {
 		return c.postMultipart(ctx, apiSetChatPhoto, options, nil, &multiPartFile{photo, "photo"})
@@ -0,0 +1,154 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestChatMemberUpdated(t *testing.T) {
+	member := func(status string) *ChatMember { return &ChatMember{Status: status} }
+	private := &Chat{Type: "private"}
+	group := &Chat{Type: "supergroup"}
+
+	tests := []struct {
+		name                                           string
+		update                                         ChatMemberUpdated
+		joined, left, promoted, restricted, botBlocked bool
+	}{
+		{"joined", ChatMemberUpdated{Chat: group, OldChatMember: member("left"), NewChatMember: member("member")}, true, false, false, false, false},
+		{"joined restricted", ChatMemberUpdated{Chat: group, OldChatMember: member("kicked"), NewChatMember: &ChatMember{Status: "restricted", IsMember: true}}, true, false, false, true, false},
+		{"left", ChatMemberUpdated{Chat: group, OldChatMember: member("member"), NewChatMember: member("left")}, false, true, false, false, false},
+		{"banned", ChatMemberUpdated{Chat: group, OldChatMember: member("administrator"), NewChatMember: member("kicked")}, false, true, false, false, false},
+		{"promoted", ChatMemberUpdated{Chat: group, OldChatMember: member("member"), NewChatMember: member("administrator")}, false, false, true, false, false},
+		{"restricted", ChatMemberUpdated{Chat: group, OldChatMember: member("member"), NewChatMember: &ChatMember{Status: "restricted", IsMember: true}}, false, false, false, true, false},
+		{"bot blocked", ChatMemberUpdated{Chat: private, OldChatMember: member("member"), NewChatMember: member("kicked")}, false, true, false, false, true},
+	}
+	for _, tt := range tests {
+		u := tt.update
+		if got := u.Joined(); got != tt.joined {
+			t.Errorf("%s: Joined is %v; want %v", tt.name, got, tt.joined)
+		}
+		if got := u.Left(); got != tt.left {
+			t.Errorf("%s: Left is %v; want %v", tt.name, got, tt.left)
+		}
+		if got := u.Promoted(); got != tt.promoted {
+			t.Errorf("%s: Promoted is %v; want %v", tt.name, got, tt.promoted)
+		}
+		if got := u.Restricted(); got != tt.restricted {
+			t.Errorf("%s: Restricted is %v; want %v", tt.name, got, tt.restricted)
+		}
+		if got := u.BotBlocked(); got != tt.botBlocked {
+			t.Errorf("%s: BotBlocked is %v; want %v", tt.name, got, tt.botBlocked)
+		}
+	}
+}
+
+func TestUpdate_ChatMember(t *testing.T) {
+	var update Update
+	err := json.Unmarshal([]byte(`{
+		"update_id": 1,
+		"chat_member": {
+			"chat": {"id": -100, "type": "supergroup"},
+			"from": {"id": 7},
+			"date": 1600000000,
+			"old_chat_member": {"user": {"id": 8}, "status": "left"},
+			"new_chat_member": {"user": {"id": 8}, "status": "member"},
+			"invite_link": {"invite_link": "https://t.me/joinchat/abc", "creator": {"id": 7}}
+		}
+	}`), &update)
+	if err != nil {
+		t.Fatalf("Unmarshal returned error %v", err)
+	}
+
+	if got := update.Type(); got != UpdateTypeChatMember {
+		t.Errorf("Type is %q; want %q", got, UpdateTypeChatMember)
+	}
+	if chat := update.EffectiveChat(); chat == nil || chat.Id != -100 {
+		t.Errorf("EffectiveChat is %+v; want chat -100", chat)
+	}
+	if user := update.EffectiveUser(); user == nil || user.Id != 7 {
+		t.Errorf("EffectiveUser is %+v; want user 7", user)
+	}
+	if !update.ChatMember.Joined() || update.ChatMember.InviteLink.InviteLink != "https://t.me/joinchat/abc" {
+		t.Errorf("ChatMember is %+v; want join with invite link", update.ChatMember)
+	}
+}
+
+func TestBotClient_CreateChatInviteLink(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := CreateChatInviteLinkOptions{
+		ChatId:             Int(-100),
+		Name:               String("spring campaign"),
+		ExpireDate:         Int(1700000000),
+		CreatesJoinRequest: Bool(true),
+	}
+
+	mux.HandleFunc("/createChatInviteLink", func(w http.ResponseWriter, r *http.Request) {
+		v := new(CreateChatInviteLinkOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w,
+			`{
+    					"ok": true,
+    					"result": {
+    						"invite_link": "https://t.me/+abc",
+    						"creator": {"id": 1, "is_bot": true},
+    						"creates_join_request": true,
+    						"is_primary": false,
+    						"is_revoked": false,
+    						"name": "spring campaign",
+    						"expire_date": 1700000000
+    					}
+					}`,
+		)
+	})
+
+	got, err := b.CreateChatInviteLink(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("CreateChatInviteLink returned error %v", err)
+	}
+	want := &ChatInviteLink{
+		InviteLink:         "https://t.me/+abc",
+		Creator:            &User{Id: 1, IsBot: true},
+		CreatesJoinRequest: true,
+		Name:               "spring campaign",
+		ExpireDate:         1700000000,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateChatInviteLink returned %+v; want %+v", got, want)
+	}
+}
+
+func TestBotClient_ApproveChatJoinRequest(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := ChatJoinRequestOptions{ChatId: Int(-100), UserId: Int(8)}
+
+	mux.HandleFunc("/approveChatJoinRequest", func(w http.ResponseWriter, r *http.Request) {
+		v := new(ChatJoinRequestOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": true}`)
+	})
+
+	if err := b.ApproveChatJoinRequest(context.Background(), opts); err != nil {
+		t.Errorf("ApproveChatJoinRequest returned error %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ConversationEnd is returned by a state handler to end the conversation.
+const ConversationEnd = ""
+
+// ConversationKey identifies a conversation with a user in a chat.
+type ConversationKey struct {
+	ChatId int
+	UserId int
+}
+
+// UpdateConversationKey returns the conversation key of a message or callback
+// query update.
+func UpdateConversationKey(update *Update) (ConversationKey, bool) {
+	switch {
+	case update.Message != nil && update.Message.Chat != nil && update.Message.From != nil:
+		return ConversationKey{update.Message.Chat.Id, update.Message.From.Id}, true
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil && update.CallbackQuery.Message.Chat != nil && update.CallbackQuery.From != nil:
+		return ConversationKey{update.CallbackQuery.Message.Chat.Id, update.CallbackQuery.From.Id}, true
+	}
+	return ConversationKey{}, false
+}
+
+// ConversationState is the persisted state of a conversation. Handlers may
+// keep the answers collected so far in Data.
+type ConversationState struct {
+	State     string            `json:"state"`
+	Data      map[string]string `json:"data,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type ConversationStorage interface {
+	// GetState returns nil if there is no conversation for key.
+	GetState(ctx context.Context, key ConversationKey) (*ConversationState, error)
+	SetState(ctx context.Context, key ConversationKey, state *ConversationState) error
+	DeleteState(ctx context.Context, key ConversationKey) error
+}
+
+// StateHandlerFunc handles an update received in a state and returns the
+// name of the next state, or ConversationEnd.
+type StateHandlerFunc func(ctx context.Context, update *Update, state *ConversationState) (string, error)
+
+// Conversation routes the updates of a user in a chat to the handler of the
+// state their conversation is in. Conversations start with an entry command
+// and move between the declared states.
+type Conversation struct {
+	storage ConversationStorage
+	entries map[string]*conversationStep
+	states  map[string]*conversationStep
+
+	// Timeout ends conversations idle for longer than it, if non-zero.
+	Timeout time.Duration
+	// CancelCommands end the conversation from any state.
+	CancelCommands []string
+	// AllowReentry restarts the conversation when an entry command is
+	// received while it is running, instead of passing it to the current state.
+	AllowReentry bool
+	OnTimeout    Handler
+	OnCancel     Handler
+}
+
+type conversationStep struct {
+	handler StateHandlerFunc
+	next    map[string]bool
+}
+
+func NewConversation(storage ConversationStorage) *Conversation {
+	return &Conversation{
+		storage:        storage,
+		entries:        make(map[string]*conversationStep),
+		states:         make(map[string]*conversationStep),
+		CancelCommands: []string{"/cancel"},
+	}
+}
+
+func newConversationStep(handler StateHandlerFunc, next []string) *conversationStep {
+	step := &conversationStep{handler: handler, next: map[string]bool{ConversationEnd: true}}
+	for _, name := range next {
+		step.next[name] = true
+	}
+	return step
+}
+
+// Entry starts the conversation when command is received. The handler may
+// move to any of the next states.
+func (c *Conversation) Entry(command string, handler StateHandlerFunc, next ...string) {
+	c.entries[command] = newConversationStep(handler, next)
+}
+
+// State declares a state, its handler and the states it may move to.
+func (c *Conversation) State(name string, handler StateHandlerFunc, next ...string) {
+	c.states[name] = newConversationStep(handler, next)
+}
+
+// Register routes the updates of running conversations and the entry
+// commands to the conversation. It should be registered before other routes.
+func (c *Conversation) Register(r *Router) {
+	r.Handle(c.Filter(), c)
+}
+
+// Filter matches entry commands and the updates of running conversations.
+func (c *Conversation) Filter() Filter {
+	return func(ctx context.Context, update *Update) (context.Context, bool) {
+		if _, ok := c.entries[updateCommand(update)]; ok {
+			return ctx, true
+		}
+		key, ok := UpdateConversationKey(update)
+		if !ok {
+			return ctx, false
+		}
+		state, err := c.storage.GetState(ctx, key)
+		return ctx, err == nil && state != nil
+	}
+}
+
+func (c *Conversation) HandleUpdate(ctx context.Context, update *Update) error {
+	key, ok := UpdateConversationKey(update)
+	if !ok {
+		return nil
+	}
+
+	state, err := c.storage.GetState(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	command := updateCommand(update)
+	entry := c.entries[command]
+
+	if state != nil && c.Timeout > 0 && time.Since(state.UpdatedAt) > c.Timeout {
+		if err := c.storage.DeleteState(ctx, key); err != nil {
+			return err
+		}
+		state = nil
+		if entry == nil {
+			if c.OnTimeout != nil {
+				return c.OnTimeout.HandleUpdate(ctx, update)
+			}
+			return nil
+		}
+	}
+
+	if state != nil {
+		for _, cancel := range c.CancelCommands {
+			if command != "" && command == cancel {
+				if err := c.storage.DeleteState(ctx, key); err != nil {
+					return err
+				}
+				if c.OnCancel != nil {
+					return c.OnCancel.HandleUpdate(ctx, update)
+				}
+				return nil
+			}
+		}
+	}
+
+	var step *conversationStep
+	switch {
+	case entry != nil && (state == nil || c.AllowReentry):
+		step = entry
+		state = &ConversationState{Data: make(map[string]string)}
+	case state != nil:
+		step = c.states[state.State]
+		if step == nil {
+			return fmt.Errorf("conversation state %q is not declared", state.State)
+		}
+	default:
+		return nil
+	}
+
+	next, err := step.handler(ctx, update, state)
+	if err != nil {
+		return err
+	}
+	if !step.next[next] {
+		return fmt.Errorf("conversation cannot move from state %q to %q", state.State, next)
+	}
+	if next == ConversationEnd {
+		return c.storage.DeleteState(ctx, key)
+	}
+
+	state.State = next
+	state.UpdatedAt = time.Now()
+	return c.storage.SetState(ctx, key, state)
+}
+
+func updateCommand(update *Update) string {
+	if update.Message == nil {
+		return ""
+	}
+	return update.Message.Command()
+}
+
+// MemoryConversationStorage is a ConversationStorage keeping states in memory.
+type MemoryConversationStorage struct {
+	mu     sync.Mutex
+	states map[ConversationKey]ConversationState
+}
+
+func NewMemoryConversationStorage() *MemoryConversationStorage {
+	return &MemoryConversationStorage{
+		states: make(map[ConversationKey]ConversationState),
+	}
+}
+
+func (s *MemoryConversationStorage) GetState(_ context.Context, key ConversationKey) (*ConversationState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		return nil, nil
+	}
+	data := make(map[string]string, len(state.Data))
+	for k, v := range state.Data {
+		data[k] = v
+	}
+	state.Data = data
+	return &state, nil
+}
+
+func (s *MemoryConversationStorage) SetState(_ context.Context, key ConversationKey, state *ConversationState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[key] = *state
+	return nil
+}
+
+func (s *MemoryConversationStorage) DeleteState(_ context.Context, key ConversationKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, key)
+	return nil
+}
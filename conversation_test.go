@@ -0,0 +1,112 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func testCommandUpdate(text string) *Update {
+	update := &Update{Message: &Message{
+		Text: text,
+		Chat: &Chat{Id: 1},
+		From: &User{Id: 2},
+	}}
+	if text[0] == '/' {
+		update.Message.Entities = []MessageEntity{{Type: "bot_command", Offset: 0, Length: len(text)}}
+	}
+	return update
+}
+
+func TestConversation(t *testing.T) {
+	storage := NewMemoryConversationStorage()
+	conv := NewConversation(storage)
+	conv.Entry("/start", func(ctx context.Context, update *Update, state *ConversationState) (string, error) {
+		return "name", nil
+	}, "name")
+	conv.State("name", func(ctx context.Context, update *Update, state *ConversationState) (string, error) {
+		state.Data["name"] = update.Message.Text
+		return "age", nil
+	}, "age")
+	conv.State("age", func(ctx context.Context, update *Update, state *ConversationState) (string, error) {
+		if state.Data["name"] != "Alice" {
+			t.Errorf("name is %q; want Alice", state.Data["name"])
+		}
+		return ConversationEnd, nil
+	})
+
+	r := NewRouter()
+	conv.Register(r)
+	var unrouted int
+	r.NotFound(HandlerFunc(func(ctx context.Context, update *Update) error {
+		unrouted++
+		return nil
+	}))
+
+	key := ConversationKey{ChatId: 1, UserId: 2}
+	steps := []struct {
+		text string
+		want string
+	}{
+		{"hello", ""},
+		{"/start", "name"},
+		{"Alice", "age"},
+		{"30", ""},
+	}
+	for _, step := range steps {
+		if err := r.HandleUpdate(context.Background(), testCommandUpdate(step.text)); err != nil {
+			t.Fatalf("HandleUpdate(%q) returned error %v", step.text, err)
+		}
+		state, _ := storage.GetState(context.Background(), key)
+		got := ""
+		if state != nil {
+			got = state.State
+		}
+		if got != step.want {
+			t.Errorf("after %q state is %q; want %q", step.text, got, step.want)
+		}
+	}
+	if unrouted != 1 {
+		t.Errorf("%d updates were not routed to the conversation; want 1", unrouted)
+	}
+}
+
+func TestConversation_TimeoutAndCancel(t *testing.T) {
+	storage := NewMemoryConversationStorage()
+	conv := NewConversation(storage)
+	conv.Timeout = time.Minute
+	conv.State("name", func(ctx context.Context, update *Update, state *ConversationState) (string, error) {
+		t.Errorf("state handler called for %q", update.Message.Text)
+		return ConversationEnd, nil
+	})
+
+	var timedOut, cancelled bool
+	conv.OnTimeout = HandlerFunc(func(ctx context.Context, update *Update) error {
+		timedOut = true
+		return nil
+	})
+	conv.OnCancel = HandlerFunc(func(ctx context.Context, update *Update) error {
+		cancelled = true
+		return nil
+	})
+
+	key := ConversationKey{ChatId: 1, UserId: 2}
+	storage.SetState(context.Background(), key, &ConversationState{State: "name", UpdatedAt: time.Now().Add(-time.Hour)})
+	if err := conv.HandleUpdate(context.Background(), testCommandUpdate("Alice")); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+	if !timedOut {
+		t.Errorf("OnTimeout was not called")
+	}
+
+	storage.SetState(context.Background(), key, &ConversationState{State: "name", UpdatedAt: time.Now()})
+	if err := conv.HandleUpdate(context.Background(), testCommandUpdate("/cancel")); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+	if !cancelled {
+		t.Errorf("OnCancel was not called")
+	}
+	if state, _ := storage.GetState(context.Background(), key); state != nil {
+		t.Errorf("state is %+v after cancel; want nil", state)
+	}
+}
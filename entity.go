@@ -0,0 +1,397 @@
+package telegram
+
+import (
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	EntityTypeMention       = "mention"
+	EntityTypeHashtag       = "hashtag"
+	EntityTypeCashtag       = "cashtag"
+	EntityTypeBotCommand    = "bot_command"
+	EntityTypeURL           = "url"
+	EntityTypeEmail         = "email"
+	EntityTypePhoneNumber   = "phone_number"
+	EntityTypeBold          = "bold"
+	EntityTypeItalic        = "italic"
+	EntityTypeUnderline     = "underline"
+	EntityTypeStrikethrough = "strikethrough"
+	EntityTypeSpoiler       = "spoiler"
+	EntityTypeCode          = "code"
+	EntityTypePre           = "pre"
+	EntityTypeTextLink      = "text_link"
+	EntityTypeTextMention   = "text_mention"
+)
+
+// TextHTML renders the message text and its entities as Telegram HTML.
+func (m *Message) TextHTML() string {
+	return RenderHTML(m.Text, m.Entities)
+}
+
+// TextMarkdownV2 renders the message text and its entities as Telegram MarkdownV2.
+func (m *Message) TextMarkdownV2() string {
+	return RenderMarkdownV2(m.Text, m.Entities)
+}
+
+// TextPlain renders the message text with the targets of text links inlined.
+func (m *Message) TextPlain() string {
+	return RenderPlain(m.Text, m.Entities)
+}
+
+// CaptionHTML renders the message caption and its entities as Telegram HTML.
+func (m *Message) CaptionHTML() string {
+	return RenderHTML(m.Caption, m.CaptionEntities)
+}
+
+// CaptionMarkdownV2 renders the message caption and its entities as Telegram MarkdownV2.
+func (m *Message) CaptionMarkdownV2() string {
+	return RenderMarkdownV2(m.Caption, m.CaptionEntities)
+}
+
+// CaptionPlain renders the message caption with the targets of text links inlined.
+func (m *Message) CaptionPlain() string {
+	return RenderPlain(m.Caption, m.CaptionEntities)
+}
+
+// EntityText returns the part of text covered by entity. Entity offsets and
+// lengths are measured in UTF-16 code units.
+func EntityText(text string, entity MessageEntity) string {
+	units := utf16.Encode([]rune(text))
+	start, end := clampEntity(entity, len(units))
+	return string(utf16.Decode(units[start:end]))
+}
+
+func clampEntity(entity MessageEntity, size int) (int, int) {
+	start := entity.Offset
+	end := entity.Offset + entity.Length
+	if start < 0 {
+		start = 0
+	}
+	if end > size {
+		end = size
+	}
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+// MessageEntityValues holds the values of the auto-detected entities of a message.
+type MessageEntityValues struct {
+	URLs     []string
+	Mentions []string
+	Hashtags []string
+	Cashtags []string
+}
+
+// EntityValues extracts urls, mentions, hashtags and cashtags from both the
+// text and the caption of the message.
+func (m *Message) EntityValues() MessageEntityValues {
+	var values MessageEntityValues
+	values.add(m.Text, m.Entities)
+	values.add(m.Caption, m.CaptionEntities)
+	return values
+}
+
+// ExtractEntityValues extracts urls, mentions, hashtags and cashtags from text.
+// Text links contribute their target url and text mentions the username of
+// the mentioned user, if any.
+func ExtractEntityValues(text string, entities []MessageEntity) MessageEntityValues {
+	var values MessageEntityValues
+	values.add(text, entities)
+	return values
+}
+
+func (v *MessageEntityValues) add(text string, entities []MessageEntity) {
+	for _, entity := range entities {
+		switch entity.Type {
+		case EntityTypeURL:
+			v.URLs = append(v.URLs, EntityText(text, entity))
+		case EntityTypeTextLink:
+			v.URLs = append(v.URLs, entity.URL)
+		case EntityTypeMention:
+			v.Mentions = append(v.Mentions, EntityText(text, entity))
+		case EntityTypeTextMention:
+			if entity.User != nil && entity.User.Username != "" {
+				v.Mentions = append(v.Mentions, "@"+entity.User.Username)
+			}
+		case EntityTypeHashtag:
+			v.Hashtags = append(v.Hashtags, EntityText(text, entity))
+		case EntityTypeCashtag:
+			v.Cashtags = append(v.Cashtags, EntityText(text, entity))
+		}
+	}
+}
+
+// RenderHTML renders text and its entities as Telegram HTML. Nested and
+// partially overlapping entities are supported.
+func RenderHTML(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, htmlRenderer{})
+}
+
+// RenderMarkdownV2 renders text and its entities as Telegram MarkdownV2.
+// Nested and partially overlapping entities are supported.
+func RenderMarkdownV2(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, &markdownV2Renderer{})
+}
+
+// RenderPlain returns text without formatting, appending the target of every
+// text link after the linked text.
+func RenderPlain(text string, entities []MessageEntity) string {
+	return renderEntities(text, entities, plainRenderer{})
+}
+
+type entityRenderer interface {
+	open(b *strings.Builder, entity *MessageEntity)
+	close(b *strings.Builder, entity *MessageEntity, ended bool)
+	text(b *strings.Builder, s string, enclosing []*MessageEntity)
+}
+
+// renderEntities walks text in UTF-16 code units and emits the opening and
+// closing markup of every entity at its boundaries. Entities that overlap
+// partially are closed and reopened so that the output is always well nested.
+func renderEntities(text string, entities []MessageEntity, r entityRenderer) string {
+	if len(entities) == 0 {
+		var b strings.Builder
+		r.text(&b, text, nil)
+		return b.String()
+	}
+
+	units := utf16.Encode([]rune(text))
+
+	sorted := make([]*MessageEntity, 0, len(entities))
+	boundaries := []int{0, len(units)}
+	for i := range entities {
+		start, end := clampEntity(entities[i], len(units))
+		if start == end {
+			continue
+		}
+		e := entities[i]
+		e.Offset, e.Length = start, end-start
+		sorted = append(sorted, &e)
+		boundaries = append(boundaries, start, end)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset != sorted[j].Offset {
+			return sorted[i].Offset < sorted[j].Offset
+		}
+		return sorted[i].Length > sorted[j].Length
+	})
+	sort.Ints(boundaries)
+	unique := boundaries[:1]
+	for _, pos := range boundaries[1:] {
+		if pos != unique[len(unique)-1] {
+			unique = append(unique, pos)
+		}
+	}
+	boundaries = unique
+
+	var b strings.Builder
+	var stack []*MessageEntity
+	next := 0
+	for i, pos := range boundaries {
+		// Close every entity ending here, temporarily closing the entities
+		// opened after it that are still running.
+		depth := len(stack)
+		for j, e := range stack {
+			if e.Offset+e.Length == pos {
+				depth = j
+				break
+			}
+		}
+		var reopen []*MessageEntity
+		for j := len(stack) - 1; j >= depth; j-- {
+			ended := stack[j].Offset+stack[j].Length == pos
+			r.close(&b, stack[j], ended)
+			if !ended {
+				reopen = append(reopen, stack[j])
+			}
+		}
+		stack = stack[:depth]
+		for j := len(reopen) - 1; j >= 0; j-- {
+			r.open(&b, reopen[j])
+			stack = append(stack, reopen[j])
+		}
+
+		for next < len(sorted) && sorted[next].Offset == pos {
+			r.open(&b, sorted[next])
+			stack = append(stack, sorted[next])
+			next++
+		}
+
+		if i+1 < len(boundaries) {
+			r.text(&b, string(utf16.Decode(units[pos:boundaries[i+1]])), stack)
+		}
+	}
+	return b.String()
+}
+
+type htmlRenderer struct{}
+
+func (htmlRenderer) open(b *strings.Builder, e *MessageEntity) {
+	switch e.Type {
+	case EntityTypeBold:
+		b.WriteString("<b>")
+	case EntityTypeItalic:
+		b.WriteString("<i>")
+	case EntityTypeUnderline:
+		b.WriteString("<u>")
+	case EntityTypeStrikethrough:
+		b.WriteString("<s>")
+	case EntityTypeSpoiler:
+		b.WriteString("<tg-spoiler>")
+	case EntityTypeCode:
+		b.WriteString("<code>")
+	case EntityTypePre:
+		if e.Language != "" {
+			b.WriteString(`<pre><code class="language-` + html.EscapeString(e.Language) + `">`)
+		} else {
+			b.WriteString("<pre>")
+		}
+	case EntityTypeTextLink:
+		b.WriteString(`<a href="` + html.EscapeString(e.URL) + `">`)
+	case EntityTypeTextMention:
+		if e.User != nil {
+			b.WriteString(`<a href="tg://user?id=` + strconv.Itoa(e.User.Id) + `">`)
+		}
+	}
+}
+
+func (htmlRenderer) close(b *strings.Builder, e *MessageEntity, _ bool) {
+	switch e.Type {
+	case EntityTypeBold:
+		b.WriteString("</b>")
+	case EntityTypeItalic:
+		b.WriteString("</i>")
+	case EntityTypeUnderline:
+		b.WriteString("</u>")
+	case EntityTypeStrikethrough:
+		b.WriteString("</s>")
+	case EntityTypeSpoiler:
+		b.WriteString("</tg-spoiler>")
+	case EntityTypeCode:
+		b.WriteString("</code>")
+	case EntityTypePre:
+		if e.Language != "" {
+			b.WriteString("</code></pre>")
+		} else {
+			b.WriteString("</pre>")
+		}
+	case EntityTypeTextLink:
+		b.WriteString("</a>")
+	case EntityTypeTextMention:
+		if e.User != nil {
+			b.WriteString("</a>")
+		}
+	}
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (htmlRenderer) text(b *strings.Builder, s string, _ []*MessageEntity) {
+	b.WriteString(htmlEscaper.Replace(s))
+}
+
+type markdownV2Renderer struct {
+	// underscore is set when the last thing written was an italic or
+	// underline marker, which must be separated from an adjacent marker.
+	underscore bool
+}
+
+func (r *markdownV2Renderer) marker(b *strings.Builder, s string) {
+	if r.underscore && strings.HasPrefix(s, "_") {
+		b.WriteString("\r")
+	}
+	b.WriteString(s)
+	r.underscore = strings.HasSuffix(s, "_")
+}
+
+func (r *markdownV2Renderer) open(b *strings.Builder, e *MessageEntity) {
+	switch e.Type {
+	case EntityTypeBold:
+		r.marker(b, "*")
+	case EntityTypeItalic:
+		r.marker(b, "_")
+	case EntityTypeUnderline:
+		r.marker(b, "__")
+	case EntityTypeStrikethrough:
+		r.marker(b, "~")
+	case EntityTypeSpoiler:
+		r.marker(b, "||")
+	case EntityTypeCode:
+		r.marker(b, "`")
+	case EntityTypePre:
+		r.marker(b, "```"+markdownV2CodeEscaper.Replace(e.Language)+"\n")
+	case EntityTypeTextLink, EntityTypeTextMention:
+		if e.Type == EntityTypeTextLink || e.User != nil {
+			r.marker(b, "[")
+		}
+	}
+}
+
+func (r *markdownV2Renderer) close(b *strings.Builder, e *MessageEntity, _ bool) {
+	switch e.Type {
+	case EntityTypeBold:
+		r.marker(b, "*")
+	case EntityTypeItalic:
+		r.marker(b, "_")
+	case EntityTypeUnderline:
+		r.marker(b, "__")
+	case EntityTypeStrikethrough:
+		r.marker(b, "~")
+	case EntityTypeSpoiler:
+		r.marker(b, "||")
+	case EntityTypeCode:
+		r.marker(b, "`")
+	case EntityTypePre:
+		r.marker(b, "```")
+	case EntityTypeTextLink:
+		r.marker(b, "]("+markdownV2LinkEscaper.Replace(e.URL)+")")
+	case EntityTypeTextMention:
+		if e.User != nil {
+			r.marker(b, "](tg://user?id="+strconv.Itoa(e.User.Id)+")")
+		}
+	}
+}
+
+var markdownV2Escaper = strings.NewReplacer(
+	"\\", "\\\\", "_", "\\_", "*", "\\*", "[", "\\[", "]", "\\]", "(", "\\(", ")", "\\)",
+	"~", "\\~", "`", "\\`", ">", "\\>", "#", "\\#", "+", "\\+", "-", "\\-", "=", "\\=",
+	"|", "\\|", "{", "\\{", "}", "\\}", ".", "\\.", "!", "\\!",
+)
+
+var markdownV2CodeEscaper = strings.NewReplacer("\\", "\\\\", "`", "\\`")
+
+var markdownV2LinkEscaper = strings.NewReplacer("\\", "\\\\", ")", "\\)")
+
+func (r *markdownV2Renderer) text(b *strings.Builder, s string, enclosing []*MessageEntity) {
+	if s == "" {
+		return
+	}
+	escaper := markdownV2Escaper
+	for _, e := range enclosing {
+		if e.Type == EntityTypeCode || e.Type == EntityTypePre {
+			escaper = markdownV2CodeEscaper
+		}
+	}
+	b.WriteString(escaper.Replace(s))
+	r.underscore = false
+}
+
+type plainRenderer struct{}
+
+func (plainRenderer) open(*strings.Builder, *MessageEntity) {}
+
+func (plainRenderer) close(b *strings.Builder, e *MessageEntity, ended bool) {
+	if ended && e.Type == EntityTypeTextLink && e.URL != "" {
+		b.WriteString(" (" + e.URL + ")")
+	}
+}
+
+func (plainRenderer) text(b *strings.Builder, s string, _ []*MessageEntity) {
+	b.WriteString(s)
+}
@@ -0,0 +1,138 @@
+package telegram
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []MessageEntity
+		want     string
+	}{
+		{
+			name: "escaping",
+			text: "a < b & c > d",
+			want: "a &lt; b &amp; c &gt; d",
+		},
+		{
+			name: "nested",
+			text: "bold italic",
+			entities: []MessageEntity{
+				{Type: "bold", Offset: 0, Length: 11},
+				{Type: "italic", Offset: 5, Length: 6},
+			},
+			want: "<b>bold <i>italic</i></b>",
+		},
+		{
+			name: "overlapping",
+			text: "abcdef",
+			entities: []MessageEntity{
+				{Type: "bold", Offset: 0, Length: 4},
+				{Type: "italic", Offset: 2, Length: 4},
+			},
+			want: "<b>ab<i>cd</i></b><i>ef</i>",
+		},
+		{
+			name: "utf16 offsets",
+			text: "😀 link",
+			entities: []MessageEntity{
+				{Type: "text_link", Offset: 3, Length: 4, URL: "https://example.com/?a=1&b=2"},
+			},
+			want: `😀 <a href="https://example.com/?a=1&amp;b=2">link</a>`,
+		},
+		{
+			name: "pre with language",
+			text: "x := 1",
+			entities: []MessageEntity{
+				{Type: "pre", Offset: 0, Length: 6, Language: "go"},
+			},
+			want: `<pre><code class="language-go">x := 1</code></pre>`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := RenderHTML(tt.text, tt.entities); got != tt.want {
+			t.Errorf("%s: RenderHTML = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMarkdownV2(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []MessageEntity
+		want     string
+	}{
+		{
+			name: "escaping",
+			text: "1 + 1 = 2.",
+			want: `1 \+ 1 \= 2\.`,
+		},
+		{
+			name: "code escaping",
+			text: "a_b `c`",
+			entities: []MessageEntity{
+				{Type: "code", Offset: 0, Length: 7},
+			},
+			want: "`a_b \\`c\\``",
+		},
+		{
+			name: "italic inside underline",
+			text: "text",
+			entities: []MessageEntity{
+				{Type: "underline", Offset: 0, Length: 4},
+				{Type: "italic", Offset: 0, Length: 4},
+			},
+			want: "__\r_text_\r__",
+		},
+		{
+			name: "text mention",
+			text: "hi John",
+			entities: []MessageEntity{
+				{Type: "text_mention", Offset: 3, Length: 4, User: &User{Id: 42}},
+			},
+			want: "hi [John](tg://user?id=42)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := RenderMarkdownV2(tt.text, tt.entities); got != tt.want {
+			t.Errorf("%s: RenderMarkdownV2 = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	entities := []MessageEntity{{Type: "text_link", Offset: 4, Length: 4, URL: "https://example.com"}}
+	if got, want := RenderPlain("see docs", entities), "see docs (https://example.com)"; got != want {
+		t.Errorf("RenderPlain = %q; want %q", got, want)
+	}
+}
+
+func TestMessage_EntityValues(t *testing.T) {
+	m := &Message{
+		Text: "@alice paid $USD for #coffee at https://example.com",
+		Entities: []MessageEntity{
+			{Type: "mention", Offset: 0, Length: 6},
+			{Type: "cashtag", Offset: 12, Length: 4},
+			{Type: "hashtag", Offset: 21, Length: 7},
+			{Type: "url", Offset: 32, Length: 19},
+		},
+		Caption:         "docs",
+		CaptionEntities: []MessageEntity{{Type: "text_link", Offset: 0, Length: 4, URL: "https://docs.example.com"}},
+	}
+
+	want := MessageEntityValues{
+		URLs:     []string{"https://example.com", "https://docs.example.com"},
+		Mentions: []string{"@alice"},
+		Hashtags: []string{"#coffee"},
+		Cashtags: []string{"$USD"},
+	}
+	if got := m.EntityValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("EntityValues = %+v; want %+v", got, want)
+	}
+}
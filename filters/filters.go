@@ -0,0 +1,309 @@
+// Package filters provides predicates over updates to route them with a
+// telegram.Router, and combinators to compose them.
+package filters
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	telegram "github.com/ccl17"
+)
+
+type Filter = telegram.Filter
+
+// Func adapts a predicate over updates to a Filter.
+func Func(predicate func(update *telegram.Update) bool) Filter {
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		return ctx, predicate(update)
+	}
+}
+
+// Message adapts a predicate over messages to a Filter, matching new and
+// edited messages and channel posts.
+func Message(predicate func(message *telegram.Message) bool) Filter {
+	return Func(func(update *telegram.Update) bool {
+		m := message(update)
+		return m != nil && predicate(m)
+	})
+}
+
+// CallbackQuery adapts a predicate over callback queries to a Filter.
+func CallbackQuery(predicate func(query *telegram.CallbackQuery) bool) Filter {
+	return Func(func(update *telegram.Update) bool {
+		return update.CallbackQuery != nil && predicate(update.CallbackQuery)
+	})
+}
+
+func message(update *telegram.Update) *telegram.Message {
+	switch {
+	case update.Message != nil:
+		return update.Message
+	case update.EditedMessage != nil:
+		return update.EditedMessage
+	case update.ChannelPost != nil:
+		return update.ChannelPost
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost
+	}
+	return nil
+}
+
+// And matches updates matched by all filters, passing the context returned by
+// each filter to the next one.
+func And(filters ...Filter) Filter {
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		for _, f := range filters {
+			var ok bool
+			if ctx, ok = f(ctx, update); !ok {
+				return ctx, false
+			}
+		}
+		return ctx, true
+	}
+}
+
+// Or matches updates matched by any of filters, using the context returned by
+// the first filter matching.
+func Or(filters ...Filter) Filter {
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		for _, f := range filters {
+			if fctx, ok := f(ctx, update); ok {
+				return fctx, true
+			}
+		}
+		return ctx, false
+	}
+}
+
+func Not(filter Filter) Filter {
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		_, ok := filter(ctx, update)
+		return ctx, !ok
+	}
+}
+
+func UpdateType(updateType string) Filter {
+	return telegram.UpdateTypeFilter(updateType)
+}
+
+// Text matches messages whose text is text.
+func Text(text string) Filter {
+	return Message(func(m *telegram.Message) bool {
+		return m.Text == text
+	})
+}
+
+type capturesContextKey struct{}
+
+// Regexp matches messages whose text, or caption if there is no text, matches
+// re. The submatches are available to the handler through Captures.
+func Regexp(re *regexp.Regexp) Filter {
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		m := message(update)
+		if m == nil {
+			return ctx, false
+		}
+		text := m.Text
+		if text == "" {
+			text = m.Caption
+		}
+		return matchRegexp(ctx, re, text)
+	}
+}
+
+// CallbackDataRegexp matches callback queries whose data matches re. The
+// submatches are available to the handler through Captures.
+func CallbackDataRegexp(re *regexp.Regexp) Filter {
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		if update.CallbackQuery == nil {
+			return ctx, false
+		}
+		return matchRegexp(ctx, re, update.CallbackQuery.Data)
+	}
+}
+
+func matchRegexp(ctx context.Context, re *regexp.Regexp, s string) (context.Context, bool) {
+	captures := re.FindStringSubmatch(s)
+	if captures == nil {
+		return ctx, false
+	}
+	return context.WithValue(ctx, capturesContextKey{}, captures), true
+}
+
+// Captures returns the submatches of the last regexp filter that matched the
+// update being handled, starting with the whole match.
+func Captures(ctx context.Context) []string {
+	captures, _ := ctx.Value(capturesContextKey{}).([]string)
+	return captures
+}
+
+// Command matches messages starting with the bot command, e.g. "/start".
+func Command(command string) Filter {
+	return Message(func(m *telegram.Message) bool {
+		return m.Command() == command
+	})
+}
+
+// HasBotCommand matches messages containing a bot command anywhere.
+func HasBotCommand() Filter {
+	return Message(func(m *telegram.Message) bool {
+		for _, e := range m.Entities {
+			if e.Type == telegram.EntityTypeBotCommand {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+const (
+	ChatTypePrivate    = "private"
+	ChatTypeGroup      = "group"
+	ChatTypeSupergroup = "supergroup"
+	ChatTypeChannel    = "channel"
+)
+
+// ChatType matches updates from chats of any of the given types.
+func ChatType(chatTypes ...string) Filter {
+	return Func(func(update *telegram.Update) bool {
+		chat := update.EffectiveChat()
+		if chat == nil {
+			return false
+		}
+		for _, t := range chatTypes {
+			if chat.Type == t {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func Private() Filter {
+	return ChatType(ChatTypePrivate)
+}
+
+// Group matches updates from groups and supergroups.
+func Group() Filter {
+	return ChatType(ChatTypeGroup, ChatTypeSupergroup)
+}
+
+func HasPhoto() Filter {
+	return Message(func(m *telegram.Message) bool {
+		return len(m.Photo) > 0
+	})
+}
+
+func HasDocument() Filter {
+	return Message(func(m *telegram.Message) bool {
+		return m.Document != nil
+	})
+}
+
+func HasLocation() Filter {
+	return Message(func(m *telegram.Message) bool {
+		return m.Location != nil
+	})
+}
+
+func IsReply() Filter {
+	return Message(func(m *telegram.Message) bool {
+		return m.ReplyToMessage != nil
+	})
+}
+
+func IsForwarded() Filter {
+	return Message(func(m *telegram.Message) bool {
+		return m.ForwardDate != 0
+	})
+}
+
+// FromUsers matches updates triggered by any of the users.
+func FromUsers(userIds ...int) Filter {
+	return Func(func(update *telegram.Update) bool {
+		user := update.EffectiveUser()
+		if user == nil {
+			return false
+		}
+		for _, id := range userIds {
+			if user.Id == id {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+type FromAdminOptions struct {
+	// CacheTTL is how long the administrators of a chat are cached, 5 minutes
+	// if zero.
+	CacheTTL time.Duration
+	// OnError is called with the errors returned by GetChatAdministrators. The
+	// update is not matched then.
+	OnError func(ctx context.Context, update *telegram.Update, err error)
+}
+
+type adminCacheEntry struct {
+	admins  map[int]bool
+	expires time.Time
+}
+
+// FromAdmin matches updates triggered by the creator or an administrator of
+// the chat. Members of private chats are considered admins. The administrators
+// of each chat are fetched once per CacheTTL.
+func FromAdmin(client *telegram.BotClient, options FromAdminOptions) Filter {
+	ttl := options.CacheTTL
+	if ttl == 0 {
+		ttl = 5 * time.Minute
+	}
+
+	var mu sync.Mutex
+	cache := make(map[int]adminCacheEntry)
+
+	return func(ctx context.Context, update *telegram.Update) (context.Context, bool) {
+		chat, user := update.EffectiveChat(), update.EffectiveUser()
+		if chat == nil || user == nil {
+			return ctx, false
+		}
+		if chat.Type == ChatTypePrivate {
+			return ctx, true
+		}
+
+		now := time.Now()
+		mu.Lock()
+		entry, ok := cache[chat.Id]
+		mu.Unlock()
+		if ok && now.Before(entry.expires) {
+			return ctx, entry.admins[user.Id]
+		}
+
+		members, err := client.GetChatAdministrators(ctx, telegram.ChatOptions{ChatId: telegram.Int(chat.Id)})
+		if err != nil {
+			if options.OnError != nil {
+				options.OnError(ctx, update, err)
+			}
+			return ctx, false
+		}
+
+		entry = adminCacheEntry{admins: make(map[int]bool), expires: now.Add(ttl)}
+		for _, member := range members {
+			if member.User != nil && (member.Status == telegram.ChatMemberStatusCreator || member.Status == telegram.ChatMemberStatusAdministrator) {
+				entry.admins[member.User.Id] = true
+			}
+		}
+		mu.Lock()
+		cache[chat.Id] = entry
+		mu.Unlock()
+		return ctx, entry.admins[user.Id]
+	}
+}
+
+// CallbackDataPrefix matches callback queries whose data starts with prefix.
+func CallbackDataPrefix(prefix string) Filter {
+	return CallbackQuery(func(query *telegram.CallbackQuery) bool {
+		return strings.HasPrefix(query.Data, prefix)
+	})
+}
@@ -0,0 +1,98 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+
+	telegram "github.com/ccl17"
+)
+
+func TestCombinators(t *testing.T) {
+	private := &telegram.Update{Message: &telegram.Message{Chat: &telegram.Chat{Type: "private"}, Photo: []telegram.PhotoSize{{}}}}
+	group := &telegram.Update{Message: &telegram.Message{Chat: &telegram.Chat{Type: "supergroup"}}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		update *telegram.Update
+		want   bool
+	}{
+		{"and", And(Private(), HasPhoto()), private, true},
+		{"and fails", And(Private(), HasPhoto()), group, false},
+		{"or", Or(Private(), Group()), group, true},
+		{"not", Not(Group()), private, true},
+		{"not fails", Not(Group()), group, false},
+	}
+	for _, tt := range tests {
+		if _, got := tt.filter(context.Background(), tt.update); got != tt.want {
+			t.Errorf("%s: filter returned %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRegexp_Captures(t *testing.T) {
+	var captures []string
+	r := telegram.NewRouter()
+	r.HandleFunc(And(Private(), Regexp(regexp.MustCompile(`^order (\d+)$`))), func(ctx context.Context, update *telegram.Update) error {
+		captures = Captures(ctx)
+		return nil
+	})
+
+	update := &telegram.Update{Message: &telegram.Message{Text: "order 42", Chat: &telegram.Chat{Type: "private"}}}
+	if err := r.HandleUpdate(context.Background(), update); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+	if want := []string{"order 42", "42"}; !reflect.DeepEqual(captures, want) {
+		t.Errorf("Captures are %v; want %v", captures, want)
+	}
+}
+
+func TestFromAdmin(t *testing.T) {
+	var calls int
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if fail {
+			fmt.Fprint(w, `{"ok": false, "error_code": 400, "description": "Bad Request: chat not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok": true, "result": [{"user": {"id": 1}, "status": "creator"}, {"user": {"id": 2}, "status": "administrator"}]}`)
+	}))
+	defer server.Close()
+
+	client, _ := telegram.NewBotClient("123:secret", nil, "")
+	client.BaseURL = server.URL
+
+	var errs []error
+	filter := FromAdmin(client, FromAdminOptions{OnError: func(ctx context.Context, update *telegram.Update, err error) {
+		errs = append(errs, err)
+	}})
+	update := func(chatId, userId int) *telegram.Update {
+		return &telegram.Update{Message: &telegram.Message{Chat: &telegram.Chat{Id: chatId, Type: "supergroup"}, From: &telegram.User{Id: userId}}}
+	}
+
+	for _, tt := range []struct {
+		userId int
+		want   bool
+	}{{1, true}, {2, true}, {3, false}} {
+		if _, got := filter(context.Background(), update(10, tt.userId)); got != tt.want {
+			t.Errorf("FromAdmin matched user %d: %v; want %v", tt.userId, got, tt.want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("FromAdmin fetched the administrators %d times; want 1", calls)
+	}
+
+	fail = true
+	if _, got := filter(context.Background(), update(20, 1)); got {
+		t.Errorf("FromAdmin matched an update although the request failed")
+	}
+	if len(errs) != 1 {
+		t.Errorf("OnError was called %d times; want 1", len(errs))
+	}
+}
@@ -0,0 +1,86 @@
+package telegram
+
+import (
+	"context"
+)
+
+type ForumTopic struct {
+	MessageThreadId   int    `json:"message_thread_id"`
+	Name              string `json:"name"`
+	IconColor         int    `json:"icon_color"`
+	IconCustomEmojiId string `json:"icon_custom_emoji_id"`
+}
+
+// ForumTopicCreated is the service message of a new topic.
+type ForumTopicCreated struct {
+	Name              string `json:"name"`
+	IconColor         int    `json:"icon_color"`
+	IconCustomEmojiId string `json:"icon_custom_emoji_id"`
+}
+
+// ForumTopicEdited is the service message of an edited topic. Name is empty if
+// unchanged, IconCustomEmojiId is nil if unchanged and empty if removed.
+type ForumTopicEdited struct {
+	Name              string  `json:"name"`
+	IconCustomEmojiId *string `json:"icon_custom_emoji_id"`
+}
+
+// ForumTopicClosed is the service message of a closed topic.
+type ForumTopicClosed struct{}
+
+// ForumTopicReopened is the service message of a reopened topic.
+type ForumTopicReopened struct{}
+
+// GetForumTopicIconStickers returns the custom emoji stickers that can be
+// used as topic icons.
+func (c *BotClient) GetForumTopicIconStickers(ctx context.Context) ([]Sticker, error) {
+	var stickers []Sticker
+	err := c.getMethod(ctx, apiGetForumTopicIconStickers, &stickers)
+	return stickers, err
+}
+
+func (c *BotClient) CreateForumTopic(ctx context.Context, options CreateForumTopicOptions) (*ForumTopic, error) {
+	var forumTopic ForumTopic
+	err := c.postJson(ctx, apiCreateForumTopic, options, &forumTopic)
+	return &forumTopic, err
+}
+
+type CreateForumTopicOptions struct {
+	ChatId            *int    `json:"chat_id,omitempty"`
+	Name              *string `json:"name,omitempty"`
+	IconColor         *int    `json:"icon_color,omitempty"`
+	IconCustomEmojiId *string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+func (c *BotClient) EditForumTopic(ctx context.Context, options EditForumTopicOptions) error {
+	return c.postJson(ctx, apiEditForumTopic, options, nil)
+}
+
+type EditForumTopicOptions struct {
+	ChatId            *int    `json:"chat_id,omitempty"`
+	MessageThreadId   *int    `json:"message_thread_id,omitempty"`
+	Name              *string `json:"name,omitempty"`
+	IconCustomEmojiId *string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+type ForumTopicOptions struct {
+	ChatId          *int `json:"chat_id,omitempty"`
+	MessageThreadId *int `json:"message_thread_id,omitempty"`
+}
+
+func (c *BotClient) CloseForumTopic(ctx context.Context, options ForumTopicOptions) error {
+	return c.postJson(ctx, apiCloseForumTopic, options, nil)
+}
+
+func (c *BotClient) ReopenForumTopic(ctx context.Context, options ForumTopicOptions) error {
+	return c.postJson(ctx, apiReopenForumTopic, options, nil)
+}
+
+// DeleteForumTopic deletes a topic along with all its messages.
+func (c *BotClient) DeleteForumTopic(ctx context.Context, options ForumTopicOptions) error {
+	return c.postJson(ctx, apiDeleteForumTopic, options, nil)
+}
+
+func (c *BotClient) UnpinAllForumTopicMessages(ctx context.Context, options ForumTopicOptions) error {
+	return c.postJson(ctx, apiUnpinAllForumTopicMessages, options, nil)
+}
@@ -0,0 +1,55 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBotClient_CreateForumTopic(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := CreateForumTopicOptions{ChatId: Int(-100), Name: String("Ticket 42"), IconColor: Int(7322096)}
+
+	mux.HandleFunc("/createForumTopic", func(w http.ResponseWriter, r *http.Request) {
+		v := new(CreateForumTopicOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": {"message_thread_id": 5, "name": "Ticket 42", "icon_color": 7322096}}`)
+	})
+
+	got, err := b.CreateForumTopic(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("CreateForumTopic returned error %v", err)
+	}
+	if want := (&ForumTopic{MessageThreadId: 5, Name: "Ticket 42", IconColor: 7322096}); !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateForumTopic returned %+v; want %+v", got, want)
+	}
+}
+
+func TestBotClient_GetForumTopicIconStickers(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/getForumTopicIconStickers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"ok": true, "result": [{"file_id": "f", "file_unique_id": "u", "type": "custom_emoji", "emoji": "📰", "custom_emoji_id": "123"}]}`)
+	})
+
+	got, err := b.GetForumTopicIconStickers(context.Background())
+	if err != nil {
+		t.Fatalf("GetForumTopicIconStickers returned error %v", err)
+	}
+	want := []Sticker{{FileId: "f", FileUniqueId: "u", Type: "custom_emoji", Emoji: "📰", CustomEmojiId: "123"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetForumTopicIconStickers returned %+v; want %+v", got, want)
+	}
+}
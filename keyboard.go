@@ -12,6 +12,7 @@ type KeyboardButtonOptions struct {
 	RequestContact  *bool                          `json:"request_contact,omitempty"`
 	RequestLocation *bool                          `json:"request_location,omitempty"`
 	RequestPoll     *KeyboardButtonPollTypeOptions `json:"request_poll,omitempty"`
+	WebApp          *WebAppInfo                    `json:"web_app,omitempty"`
 }
 
 type KeyboardButtonPollTypeOptions struct {
@@ -34,6 +35,7 @@ type InlineKeyboardButtonOptions struct {
 	CallbackData                 *string          `json:"callback_data,omitempty"`
 	SwitchInlineQuery            *string          `json:"switch_inline_query,omitempty"`
 	SwitchInlineQueryCurrentChat *string          `json:"switch_inline_query_current_chat,omitempty"`
+	WebApp                       *WebAppInfo      `json:"web_app,omitempty"`
 	//CallbackGame                 *CallbackGame `json:"callback_game,omitempty"`
 	//Pay                          bool          `json:"pay,omitempty"`
 }
@@ -0,0 +1,262 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	MaxCallbackDataLength     = 64
+	MaxInlineKeyboardButtons  = 100
+	MaxInlineKeyboardRowWidth = 8
+)
+
+const (
+	PollTypeQuiz    = "quiz"
+	PollTypeRegular = "regular"
+)
+
+// InlineKeyboard builds an InlineKeyboardMarkupOptions row by row.
+type InlineKeyboard struct {
+	rows [][]InlineKeyboardButtonOptions
+}
+
+func NewInlineKeyboard() *InlineKeyboard {
+	return &InlineKeyboard{}
+}
+
+// Row appends a row made of buttons.
+func (k *InlineKeyboard) Row(buttons ...InlineKeyboardButtonOptions) *InlineKeyboard {
+	if len(buttons) > 0 {
+		k.rows = append(k.rows, buttons)
+	}
+	return k
+}
+
+// Grid appends buttons in rows of perRow buttons each.
+func (k *InlineKeyboard) Grid(perRow int, buttons ...InlineKeyboardButtonOptions) *InlineKeyboard {
+	if perRow < 1 {
+		perRow = 1
+	}
+	for len(buttons) > 0 {
+		n := perRow
+		if n > len(buttons) {
+			n = len(buttons)
+		}
+		k.Row(buttons[:n]...)
+		buttons = buttons[n:]
+	}
+	return k
+}
+
+// Build validates the keyboard against the limits of the Bot API and returns
+// the markup to use as ReplyMarkup.
+func (k *InlineKeyboard) Build() (*InlineKeyboardMarkupOptions, error) {
+	count := 0
+	for i, row := range k.rows {
+		if len(row) > MaxInlineKeyboardRowWidth {
+			return nil, fmt.Errorf("inline keyboard row %d has %d buttons, at most %d allowed", i, len(row), MaxInlineKeyboardRowWidth)
+		}
+		for j := range row {
+			if err := validateInlineButton(&row[j]); err != nil {
+				return nil, fmt.Errorf("inline keyboard button %d of row %d: %w", j, i, err)
+			}
+		}
+		count += len(row)
+	}
+	if count > MaxInlineKeyboardButtons {
+		return nil, fmt.Errorf("inline keyboard has %d buttons, at most %d allowed", count, MaxInlineKeyboardButtons)
+	}
+
+	rows := make([][]InlineKeyboardButtonOptions, len(k.rows))
+	copy(rows, k.rows)
+	return &InlineKeyboardMarkupOptions{InlineKeyboard: rows}, nil
+}
+
+func validateInlineButton(button *InlineKeyboardButtonOptions) error {
+	if button.Text == nil || *button.Text == "" {
+		return errors.New("text cannot be empty")
+	}
+
+	actions := 0
+	if button.Url != nil {
+		actions++
+	}
+	if button.LoginUrl != nil {
+		actions++
+	}
+	if button.CallbackData != nil {
+		actions++
+		if l := len(*button.CallbackData); l < 1 || l > MaxCallbackDataLength {
+			return fmt.Errorf("callback data is %d bytes, must be 1-%d", l, MaxCallbackDataLength)
+		}
+	}
+	if button.SwitchInlineQuery != nil {
+		actions++
+	}
+	if button.SwitchInlineQueryCurrentChat != nil {
+		actions++
+	}
+	if button.WebApp != nil {
+		actions++
+	}
+	if actions != 1 {
+		return fmt.Errorf("exactly one optional field must be set, got %d", actions)
+	}
+	return nil
+}
+
+func CallbackButton(text, data string) InlineKeyboardButtonOptions {
+	return InlineKeyboardButtonOptions{Text: String(text), CallbackData: String(data)}
+}
+
+func URLButton(text, url string) InlineKeyboardButtonOptions {
+	return InlineKeyboardButtonOptions{Text: String(text), Url: String(url)}
+}
+
+func LoginButton(text string, loginUrl LoginUrlOptions) InlineKeyboardButtonOptions {
+	return InlineKeyboardButtonOptions{Text: String(text), LoginUrl: &loginUrl}
+}
+
+func SwitchInlineQueryButton(text, query string) InlineKeyboardButtonOptions {
+	return InlineKeyboardButtonOptions{Text: String(text), SwitchInlineQuery: String(query)}
+}
+
+func SwitchInlineQueryCurrentChatButton(text, query string) InlineKeyboardButtonOptions {
+	return InlineKeyboardButtonOptions{Text: String(text), SwitchInlineQueryCurrentChat: String(query)}
+}
+
+// WebAppButton creates a button opening the Web App at url, which must use
+// HTTPS. It is only allowed in private chats.
+func WebAppButton(text, url string) InlineKeyboardButtonOptions {
+	return InlineKeyboardButtonOptions{Text: String(text), WebApp: &WebAppInfo{Url: url}}
+}
+
+// ReplyKeyboard builds a ReplyKeyboardMarkupOptions row by row.
+type ReplyKeyboard struct {
+	rows            [][]KeyboardButtonOptions
+	resizeKeyboard  *bool
+	oneTimeKeyboard *bool
+	selective       *bool
+}
+
+func NewReplyKeyboard() *ReplyKeyboard {
+	return &ReplyKeyboard{}
+}
+
+// Row appends a row made of buttons.
+func (k *ReplyKeyboard) Row(buttons ...KeyboardButtonOptions) *ReplyKeyboard {
+	if len(buttons) > 0 {
+		k.rows = append(k.rows, buttons)
+	}
+	return k
+}
+
+// Grid appends buttons in rows of perRow buttons each.
+func (k *ReplyKeyboard) Grid(perRow int, buttons ...KeyboardButtonOptions) *ReplyKeyboard {
+	if perRow < 1 {
+		perRow = 1
+	}
+	for len(buttons) > 0 {
+		n := perRow
+		if n > len(buttons) {
+			n = len(buttons)
+		}
+		k.Row(buttons[:n]...)
+		buttons = buttons[n:]
+	}
+	return k
+}
+
+func (k *ReplyKeyboard) Resize() *ReplyKeyboard {
+	k.resizeKeyboard = Bool(true)
+	return k
+}
+
+func (k *ReplyKeyboard) OneTime() *ReplyKeyboard {
+	k.oneTimeKeyboard = Bool(true)
+	return k
+}
+
+func (k *ReplyKeyboard) Selective() *ReplyKeyboard {
+	k.selective = Bool(true)
+	return k
+}
+
+// Build validates the keyboard and returns the markup to use as ReplyMarkup.
+func (k *ReplyKeyboard) Build() (*ReplyKeyboardMarkupOptions, error) {
+	if len(k.rows) == 0 {
+		return nil, errors.New("reply keyboard has no buttons")
+	}
+	for i, row := range k.rows {
+		for j := range row {
+			if err := validateKeyboardButton(&row[j]); err != nil {
+				return nil, fmt.Errorf("reply keyboard button %d of row %d: %w", j, i, err)
+			}
+		}
+	}
+
+	rows := make([][]KeyboardButtonOptions, len(k.rows))
+	copy(rows, k.rows)
+	return &ReplyKeyboardMarkupOptions{
+		Keyboard:        rows,
+		ResizeKeyboard:  k.resizeKeyboard,
+		OneTimeKeyboard: k.oneTimeKeyboard,
+		Selective:       k.selective,
+	}, nil
+}
+
+func validateKeyboardButton(button *KeyboardButtonOptions) error {
+	if button.Text == nil || *button.Text == "" {
+		return errors.New("text cannot be empty")
+	}
+
+	requests := 0
+	if button.RequestContact != nil && *button.RequestContact {
+		requests++
+	}
+	if button.RequestLocation != nil && *button.RequestLocation {
+		requests++
+	}
+	if button.RequestPoll != nil {
+		requests++
+		if t := button.RequestPoll.Type; t != nil && *t != PollTypeQuiz && *t != PollTypeRegular {
+			return fmt.Errorf("unknown poll type %s", *t)
+		}
+	}
+	if button.WebApp != nil {
+		requests++
+	}
+	if requests > 1 {
+		return fmt.Errorf("at most one request field can be set, got %d", requests)
+	}
+	return nil
+}
+
+func TextButton(text string) KeyboardButtonOptions {
+	return KeyboardButtonOptions{Text: String(text)}
+}
+
+func RequestContactButton(text string) KeyboardButtonOptions {
+	return KeyboardButtonOptions{Text: String(text), RequestContact: Bool(true)}
+}
+
+func RequestLocationButton(text string) KeyboardButtonOptions {
+	return KeyboardButtonOptions{Text: String(text), RequestLocation: Bool(true)}
+}
+
+// WebAppKeyboardButton creates a button opening the Web App at url. The Web
+// App can send data back to the bot, received as Message.WebAppData.
+func WebAppKeyboardButton(text, url string) KeyboardButtonOptions {
+	return KeyboardButtonOptions{Text: String(text), WebApp: &WebAppInfo{Url: url}}
+}
+
+// RequestPollButton creates a button asking the user to create a poll. An
+// empty pollType allows polls of any type.
+func RequestPollButton(text, pollType string) KeyboardButtonOptions {
+	pollTypeOptions := &KeyboardButtonPollTypeOptions{}
+	if pollType != "" {
+		pollTypeOptions.Type = String(pollType)
+	}
+	return KeyboardButtonOptions{Text: String(text), RequestPoll: pollTypeOptions}
+}
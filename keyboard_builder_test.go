@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInlineKeyboard_Build(t *testing.T) {
+	got, err := NewInlineKeyboard().
+		Row(CallbackButton("Yes", "y"), CallbackButton("No", "n")).
+		Grid(2, URLButton("Docs", "https://example.com"), CallbackButton("1", "1"), CallbackButton("2", "2")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned error %v", err)
+	}
+
+	want := &InlineKeyboardMarkupOptions{
+		InlineKeyboard: [][]InlineKeyboardButtonOptions{
+			{{Text: String("Yes"), CallbackData: String("y")}, {Text: String("No"), CallbackData: String("n")}},
+			{{Text: String("Docs"), Url: String("https://example.com")}, {Text: String("1"), CallbackData: String("1")}},
+			{{Text: String("2"), CallbackData: String("2")}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build returned %+v; want %+v", got, want)
+	}
+}
+
+func TestInlineKeyboard_BuildLimits(t *testing.T) {
+	if _, err := NewInlineKeyboard().Row(CallbackButton("Long", strings.Repeat("x", 65))).Build(); err == nil {
+		t.Errorf("Build err is nil; want callback data too long")
+	}
+
+	buttons := make([]InlineKeyboardButtonOptions, 101)
+	for i := range buttons {
+		buttons[i] = CallbackButton("b", "b")
+	}
+	if _, err := NewInlineKeyboard().Grid(8, buttons...).Build(); err == nil {
+		t.Errorf("Build err is nil; want too many buttons")
+	}
+
+	if _, err := NewInlineKeyboard().Row(InlineKeyboardButtonOptions{Text: String("none")}).Build(); err == nil {
+		t.Errorf("Build err is nil; want missing optional field")
+	}
+
+	twoActions := WebAppButton("App", "https://example.com/app")
+	twoActions.Url = String("https://example.com")
+	if _, err := NewInlineKeyboard().Row(twoActions).Build(); err == nil {
+		t.Errorf("Build err is nil; want too many optional fields")
+	}
+}
+
+func TestReplyKeyboard_Build(t *testing.T) {
+	got, err := NewReplyKeyboard().
+		Row(RequestContactButton("Phone"), RequestLocationButton("Location")).
+		Row(RequestPollButton("Quiz", PollTypeQuiz), WebAppKeyboardButton("App", "https://example.com/app")).
+		Resize().
+		OneTime().
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned error %v", err)
+	}
+
+	want := &ReplyKeyboardMarkupOptions{
+		Keyboard: [][]KeyboardButtonOptions{
+			{{Text: String("Phone"), RequestContact: Bool(true)}, {Text: String("Location"), RequestLocation: Bool(true)}},
+			{{Text: String("Quiz"), RequestPoll: &KeyboardButtonPollTypeOptions{Type: String("quiz")}}, {Text: String("App"), WebApp: &WebAppInfo{Url: "https://example.com/app"}}},
+		},
+		ResizeKeyboard:  Bool(true),
+		OneTimeKeyboard: Bool(true),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build returned %+v; want %+v", got, want)
+	}
+}
@@ -36,17 +36,20 @@ func (c *BotClient) SendLocation(ctx context.Context, options SendLocationOption
 }
 
 type SendLocationOptions struct {
-	ChatId                   *int        `json:"chat_id,omitempty"`
-	Latitude                 *float64    `json:"latitude,omitempty"`
-	Longitude                *float64    `json:"longitude,omitempty"`
-	HorizontalAccuracy       *float64    `json:"horizontal_accuracy,omitempty"`
-	LivePeriod               *int        `json:"live_period,omitempty"`
-	Heading                  *int        `json:"heading,omitempty"`
-	ProximityAlertRadius     *int        `json:"proximity_alert_radius,omitempty"`
-	DisableNotification      *bool       `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int        `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool       `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{} `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Latitude                 *float64         `json:"latitude,omitempty"`
+	Longitude                *float64         `json:"longitude,omitempty"`
+	HorizontalAccuracy       *float64         `json:"horizontal_accuracy,omitempty"`
+	LivePeriod               *int             `json:"live_period,omitempty"`
+	Heading                  *int             `json:"heading,omitempty"`
+	ProximityAlertRadius     *int             `json:"proximity_alert_radius,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) EditMessageLiveLocation(ctx context.Context, options EditMessageLiveLocationOptions) (*Message, error) {
@@ -88,6 +91,7 @@ func (c *BotClient) SendVenue(ctx context.Context, options SendVenueOptions) (*M
 
 type SendVenueOptions struct {
 	ChatId                   *int                         `json:"chat_id,omitempty"`
+	MessageThreadId          *int                         `json:"message_thread_id,omitempty"`
 	Latitude                 *float64                     `json:"latitude,omitempty"`
 	Longitude                *float64                     `json:"longitude,omitempty"`
 	Title                    *string                      `json:"title,omitempty"`
@@ -97,7 +101,9 @@ type SendVenueOptions struct {
 	GooglePlaceId            *string                      `json:"google_place_id,omitempty"`
 	GooglePlaceType          *string                      `json:"google_place_type,omitempty"`
 	DisableNotification      *bool                        `json:"disable_notification,omitempty"`
+	ProtectContent           *bool                        `json:"protect_content,omitempty"`
 	ReplyToMessageId         *int                         `json:"reply_to_message_id,omitempty"`
 	AllowSendingWithoutReply *bool                        `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters             `json:"reply_parameters,omitempty"`
 	ReplyMarkup              *InlineKeyboardMarkupOptions `json:"reply_markup,omitempty"`
 }
@@ -0,0 +1,107 @@
+package telegram
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// loginFields are the query parameters sent by Telegram after a login.
+var loginFields = []string{"id", "first_name", "last_name", "username", "photo_url", "auth_date", "hash"}
+
+var (
+	ErrLoginDataInvalid = errors.New("login data is invalid or has been tampered with")
+	ErrLoginDataExpired = errors.New("login data has expired")
+)
+
+// LoginUser is a user authorized with the Login Widget or a LoginUrl button.
+type LoginUser struct {
+	User
+	PhotoUrl string
+	AuthDate time.Time
+}
+
+// VerifyLoginData checks the signature of the query parameters Telegram
+// redirects to after a login, and returns the user. Other parameters in values
+// are ignored. Data older than maxAge is rejected, unless maxAge is 0, and data
+// dated in the future is always rejected.
+func VerifyLoginData(token string, values url.Values, maxAge time.Duration) (*LoginUser, error) {
+	values = loginValues(values)
+	secret := sha256.Sum256([]byte(token))
+	if !checkDataHash(values, secret[:]) {
+		return nil, ErrLoginDataInvalid
+	}
+
+	id, err := strconv.Atoi(values.Get("id"))
+	if err != nil {
+		return nil, ErrLoginDataInvalid
+	}
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, ErrLoginDataInvalid
+	}
+
+	user := &LoginUser{
+		User: User{
+			Id:        id,
+			FirstName: values.Get("first_name"),
+			LastName:  values.Get("last_name"),
+			Username:  values.Get("username"),
+		},
+		PhotoUrl: values.Get("photo_url"),
+		AuthDate: time.Unix(authDate, 0),
+	}
+	if time.Until(user.AuthDate) > authDateSkew {
+		return nil, ErrLoginDataInvalid
+	}
+	if maxAge > 0 && time.Since(user.AuthDate) > maxAge {
+		return nil, ErrLoginDataExpired
+	}
+	return user, nil
+}
+
+// loginValues returns the login parameters of values, leaving out parameters
+// such as redirect targets that are not part of the signed data.
+func loginValues(values url.Values) url.Values {
+	login := make(url.Values, len(loginFields))
+	for _, field := range loginFields {
+		if v, ok := values[field]; ok {
+			login[field] = v
+		}
+	}
+	return login
+}
+
+// VerifyLoginData verifies values with the token of the client, see
+// VerifyLoginData.
+func (c *BotClient) VerifyLoginData(values url.Values, maxAge time.Duration) (*LoginUser, error) {
+	return VerifyLoginData(c.token, values, maxAge)
+}
+
+type loginUserContextKey struct{}
+
+// LoginRequired protects next with the login data in the query of the
+// request, responding 401 Unauthorized if it is missing or cannot be
+// verified. The user is available to next with LoginUserFromContext.
+func LoginRequired(token string, maxAge time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := VerifyLoginData(token, r.URL.Query(), maxAge)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), loginUserContextKey{}, user)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// LoginUserFromContext returns the user authorized by LoginRequired, or nil.
+func LoginUserFromContext(ctx context.Context) *LoginUser {
+	user, _ := ctx.Value(loginUserContextKey{}).(*LoginUser)
+	return user
+}
@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// loginTestValues is a test vector signed with webAppTestToken, computed with
+// openssl independently of VerifyLoginData.
+func loginTestValues() url.Values {
+	return url.Values{
+		"id":         {"7"},
+		"first_name": {"Ann"},
+		"username":   {"ann"},
+		"photo_url":  {"https://t.me/i/userpic/320/ann.jpg"},
+		"auth_date":  {"1700000000"},
+		"hash":       {"22846067e88e4c6ae52e2c229d6a4bbf51413e1d745d2b345a0925b26838ec5d"},
+	}
+}
+
+func TestVerifyLoginData(t *testing.T) {
+	values := loginTestValues()
+
+	user, err := VerifyLoginData(webAppTestToken, values, 0)
+	if err != nil {
+		t.Fatalf("VerifyLoginData returned error %v", err)
+	}
+	if user.Id != 7 || user.FirstName != "Ann" || user.Username != "ann" || user.PhotoUrl == "" || user.AuthDate.Unix() != 1700000000 {
+		t.Errorf("VerifyLoginData returned %+v", user)
+	}
+
+	if _, err := VerifyLoginData(webAppTestToken, values, time.Hour); err != ErrLoginDataExpired {
+		t.Errorf("VerifyLoginData of old data err is %v; want %v", err, ErrLoginDataExpired)
+	}
+
+	values.Set("id", "8")
+	if _, err := VerifyLoginData(webAppTestToken, values, 0); err != ErrLoginDataInvalid {
+		t.Errorf("VerifyLoginData of tampered data err is %v; want %v", err, ErrLoginDataInvalid)
+	}
+}
+
+func TestLoginRequired(t *testing.T) {
+	var got *LoginUser
+	handler := LoginRequired(webAppTestToken, 0, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = LoginUserFromContext(r.Context())
+	}))
+
+	// Parameters unrelated to the login do not break verification.
+	values := loginTestValues()
+	values.Set("next", "/orders")
+	values.Set("utm_source", "bot")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/account?"+values.Encode(), nil))
+	if w.Code != http.StatusOK || got == nil || got.Id != 7 {
+		t.Errorf("authorized request: status %d, user %+v; want 200 and user 7", w.Code, got)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/account?id=7", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("unauthorized request: status %d; want 401", w.Code)
+	}
+}
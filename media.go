@@ -71,6 +71,21 @@ type Voice struct {
 	FileSize     int    `json:"file_size"`
 }
 
+type Sticker struct {
+	FileId        string     `json:"file_id"`
+	FileUniqueId  string     `json:"file_unique_id"`
+	Type          string     `json:"type"`
+	Width         int        `json:"width"`
+	Height        int        `json:"height"`
+	IsAnimated    bool       `json:"is_animated"`
+	IsVideo       bool       `json:"is_video"`
+	Thumb         *PhotoSize `json:"thumb"`
+	Emoji         string     `json:"emoji"`
+	SetName       string     `json:"set_name"`
+	CustomEmojiId string     `json:"custom_emoji_id"`
+	FileSize      int        `json:"file_size"`
+}
+
 type Contact struct {
 	PhoneNumber string `json:"phone_number"`
 	FirstName   string `json:"first_name"`
@@ -164,15 +179,18 @@ func (c *BotClient) SendPhoto(ctx context.Context, options SendPhotoOptions, pho
 }
 
 type SendPhotoOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	Photo                    *string         `json:"photo,omitempty"`
-	Caption                  *string         `json:"caption,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Photo                    *string          `json:"photo,omitempty"`
+	Caption                  *string          `json:"caption,omitempty"`
+	ParseMode                *string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity  `json:"caption_entities,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) SendAudio(ctx context.Context, options SendAudioOptions, audio, thumb *InputFile) (*Message, error) {
@@ -197,19 +215,22 @@ func (c *BotClient) SendAudio(ctx context.Context, options SendAudioOptions, aud
 }
 
 type SendAudioOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	Audio                    *string         `json:"audio,omitempty"`
-	Caption                  *string         `json:"caption,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
-	Duration                 *int            `json:"duration,omitempty"`
-	Performer                *string         `json:"performer,omitempty"`
-	Title                    *string         `json:"title,omitempty"`
-	Thumb                    *string         `json:"thumb,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Audio                    *string          `json:"audio,omitempty"`
+	Caption                  *string          `json:"caption,omitempty"`
+	ParseMode                *string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity  `json:"caption_entities,omitempty"`
+	Duration                 *int             `json:"duration,omitempty"`
+	Performer                *string          `json:"performer,omitempty"`
+	Title                    *string          `json:"title,omitempty"`
+	Thumb                    *string          `json:"thumb,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) SendDocument(ctx context.Context, options SendDocumentOptions, document, thumb *InputFile) (*Message, error) {
@@ -234,17 +255,20 @@ func (c *BotClient) SendDocument(ctx context.Context, options SendDocumentOption
 }
 
 type SendDocumentOptions struct {
-	ChatId                      *int            `json:"chat_id,omitempty"`
-	Document                    *string         `json:"document,omitempty"`
-	Thumb                       *string         `json:"thumb,omitempty"`
-	Caption                     *string         `json:"caption,omitempty"`
-	ParseMode                   *string         `json:"parse_mode,omitempty"`
-	CaptionEntities             []MessageEntity `json:"caption_entities,omitempty"`
-	DisableContentTypeDetection *bool           `json:"disable_content_type_detection,omitempty"`
-	DisableNotification         *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId            *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply    *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup                 interface{}     `json:"reply_markup,omitempty"`
+	ChatId                      *int             `json:"chat_id,omitempty"`
+	MessageThreadId             *int             `json:"message_thread_id,omitempty"`
+	Document                    *string          `json:"document,omitempty"`
+	Thumb                       *string          `json:"thumb,omitempty"`
+	Caption                     *string          `json:"caption,omitempty"`
+	ParseMode                   *string          `json:"parse_mode,omitempty"`
+	CaptionEntities             []MessageEntity  `json:"caption_entities,omitempty"`
+	DisableContentTypeDetection *bool            `json:"disable_content_type_detection,omitempty"`
+	DisableNotification         *bool            `json:"disable_notification,omitempty"`
+	ProtectContent              *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId            *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply    *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters             *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup                 interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) SendVideo(ctx context.Context, options SendVideoOptions, video, thumb *InputFile) (*Message, error) {
@@ -269,20 +293,23 @@ func (c *BotClient) SendVideo(ctx context.Context, options SendVideoOptions, vid
 }
 
 type SendVideoOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	Video                    *string         `json:"video,omitempty"`
-	Duration                 *int            `json:"duration,omitempty"`
-	Width                    *int            `json:"width,omitempty"`
-	Height                   *int            `json:"height,omitempty"`
-	Thumb                    *string         `json:"thumb,omitempty"`
-	Caption                  *string         `json:"caption,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
-	SupportsStreaming        *bool           `json:"supports_streaming,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Video                    *string          `json:"video,omitempty"`
+	Duration                 *int             `json:"duration,omitempty"`
+	Width                    *int             `json:"width,omitempty"`
+	Height                   *int             `json:"height,omitempty"`
+	Thumb                    *string          `json:"thumb,omitempty"`
+	Caption                  *string          `json:"caption,omitempty"`
+	ParseMode                *string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity  `json:"caption_entities,omitempty"`
+	SupportsStreaming        *bool            `json:"supports_streaming,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) SendAnimation(ctx context.Context, options SendAnimationOptions, animation, thumb *InputFile) (*Message, error) {
@@ -307,20 +334,23 @@ func (c *BotClient) SendAnimation(ctx context.Context, options SendAnimationOpti
 }
 
 type SendAnimationOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	Video                    *string         `json:"video,omitempty"`
-	Duration                 *int            `json:"duration,omitempty"`
-	Width                    *int            `json:"width,omitempty"`
-	Height                   *int            `json:"height,omitempty"`
-	Thumb                    *string         `json:"thumb,omitempty"`
-	Caption                  *string         `json:"caption,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
-	SupportsStreaming        *bool           `json:"supports_streaming,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Video                    *string          `json:"video,omitempty"`
+	Duration                 *int             `json:"duration,omitempty"`
+	Width                    *int             `json:"width,omitempty"`
+	Height                   *int             `json:"height,omitempty"`
+	Thumb                    *string          `json:"thumb,omitempty"`
+	Caption                  *string          `json:"caption,omitempty"`
+	ParseMode                *string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity  `json:"caption_entities,omitempty"`
+	SupportsStreaming        *bool            `json:"supports_streaming,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) sendVoice(ctx context.Context, options SendVoiceOptions, voice *InputFile) (*Message, error) {
@@ -336,16 +366,19 @@ func (c *BotClient) sendVoice(ctx context.Context, options SendVoiceOptions, voi
 }
 
 type SendVoiceOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	Voice                    *string         `json:"voice,omitempty"`
-	Caption                  *string         `json:"caption,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
-	Duration                 *int            `json:"duration,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Voice                    *string          `json:"voice,omitempty"`
+	Caption                  *string          `json:"caption,omitempty"`
+	ParseMode                *string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity  `json:"caption_entities,omitempty"`
+	Duration                 *int             `json:"duration,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) sendVideoNote(ctx context.Context, options SendVideoNoteOptions, videoNote, thumb *InputFile) (*Message, error) {
@@ -370,15 +403,18 @@ func (c *BotClient) sendVideoNote(ctx context.Context, options SendVideoNoteOpti
 }
 
 type SendVideoNoteOptions struct {
-	ChatId                   *int        `json:"chat_id,omitempty"`
-	VideoNote                *string     `json:"voice,omitempty"`
-	Duration                 *int        `json:"duration,omitempty"`
-	Length                   *int        `json:"length,omitempty"`
-	Thumb                    *string     `json:"thumb,omitempty"`
-	DisableNotification      *bool       `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int        `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool       `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{} `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	VideoNote                *string          `json:"voice,omitempty"`
+	Duration                 *int             `json:"duration,omitempty"`
+	Length                   *int             `json:"length,omitempty"`
+	Thumb                    *string          `json:"thumb,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) SendMediaGroup(ctx context.Context, options SendMediaGroupOptions, inputs []*InputFile) ([]Message, error) {
@@ -399,9 +435,12 @@ func (c *BotClient) SendMediaGroup(ctx context.Context, options SendMediaGroupOp
 }
 
 type SendMediaGroupOptions struct {
-	ChatId                   *int        `json:"chat_id,omitempty"`
-	Media                    interface{} `json:"media,omitempty"`
-	DisableNotification      *bool       `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int        `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool       `json:"allow_sending_without_reply,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Media                    interface{}      `json:"media,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
 }
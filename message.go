@@ -2,10 +2,12 @@ package telegram
 
 import (
 	"context"
+	"strings"
 )
 
 type Message struct {
 	MessageId            int             `json:"message_id"`
+	MessageThreadId      int             `json:"message_thread_id"`
 	From                 *User           `json:"from"`
 	SenderChat           *Chat           `json:"sender_chat"`
 	Date                 int             `json:"date"`
@@ -16,6 +18,7 @@ type Message struct {
 	ForwardSignature     string          `json:"forward_signature"`
 	ForwardSenderName    string          `json:"forward_sender_name"`
 	ForwardDate          int             `json:"forward_date"`
+	IsTopicMessage       bool            `json:"is_topic_message"`
 	ReplyToMessage       *Message        `json:"reply_to_message"`
 	ViaBot               *Bot            `json:"via_bot"`
 	EditDate             int             `json:"edit_date"`
@@ -27,7 +30,7 @@ type Message struct {
 	Audio                *Audio          `json:"audio"`
 	Document             *Document       `json:"document"`
 	Photo                []PhotoSize     `json:"photo"`
-	//Sticker                 *Sticker                 `json:"sticker"`
+	Sticker              *Sticker        `json:"sticker"`
 	//Video                   *Video                   `json:"video"`
 	VideoNote       *VideoNote      `json:"video_note"`
 	Voice           *Voice          `json:"voice"`
@@ -52,6 +55,11 @@ type Message struct {
 	PinnedMessage           *Message                     `json:"pinned_message"`
 	ConnectedWebsite        string                       `json:"connected_website"`
 	ProximityAlertTriggered *ProximityAlertTriggered     `json:"proximity_alert_triggered"`
+	WebAppData              *WebAppData                  `json:"web_app_data"`
+	ForumTopicCreated       *ForumTopicCreated           `json:"forum_topic_created"`
+	ForumTopicEdited        *ForumTopicEdited            `json:"forum_topic_edited"`
+	ForumTopicClosed        *ForumTopicClosed            `json:"forum_topic_closed"`
+	ForumTopicReopened      *ForumTopicReopened          `json:"forum_topic_reopened"`
 	ReplyMarkup             *InlineKeyboardMarkupOptions `json:"reply_markup"`
 	//Invoice               *Invoice           `json:"invoice"`
 	// SuccessfulPayment     *SuccessfulPayment `json:"successful_payment"`
@@ -71,6 +79,28 @@ type MessageEntity struct {
 	Language string `json:"language"`
 }
 
+// Command returns the bot command the message starts with, without the bot
+// username, e.g. "/start". It returns an empty string if there is none.
+func (m *Message) Command() string {
+	if len(m.Entities) == 0 || m.Entities[0].Type != EntityTypeBotCommand || m.Entities[0].Offset != 0 {
+		return ""
+	}
+	command := EntityText(m.Text, m.Entities[0])
+	if i := strings.Index(command, "@"); i >= 0 {
+		command = command[:i]
+	}
+	return command
+}
+
+// CommandArguments returns the text following the bot command of the message.
+func (m *Message) CommandArguments() string {
+	if m.Command() == "" {
+		return ""
+	}
+	command := EntityText(m.Text, m.Entities[0])
+	return strings.TrimSpace(strings.TrimPrefix(m.Text, command))
+}
+
 func (c *BotClient) SendMessage(ctx context.Context, options SendMessageOptions) (*Message, error) {
 	var message Message
 	err := c.postJson(ctx, apiSendMessage, options, &message)
@@ -78,15 +108,42 @@ func (c *BotClient) SendMessage(ctx context.Context, options SendMessageOptions)
 }
 
 type SendMessageOptions struct {
+	ChatId                   *int                `json:"chat_id,omitempty"`
+	MessageThreadId          *int                `json:"message_thread_id,omitempty"`
+	Text                     *string             `json:"text,omitempty"`
+	ParseMode                *string             `json:"parse_mode,omitempty"`
+	Entities                 []MessageEntity     `json:"entities,omitempty"`
+	DisableWebPagePreview    *bool               `json:"disable_web_page_preview,omitempty"`
+	LinkPreviewOptions       *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	DisableNotification      *bool               `json:"disable_notification,omitempty"`
+	ProtectContent           *bool               `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int                `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool               `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters    `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}         `json:"reply_markup,omitempty"`
+}
+
+// ReplyParameters describes the message to reply to, possibly in another
+// chat, and the part of it to quote. It replaces ReplyToMessageId and
+// AllowSendingWithoutReply, which remain for servers predating it.
+type ReplyParameters struct {
+	MessageId                *int            `json:"message_id,omitempty"`
 	ChatId                   *int            `json:"chat_id,omitempty"`
-	Text                     *string         `json:"text,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	Entities                 []MessageEntity `json:"entities,omitempty"`
-	DisableWebPagePreview    *bool           `json:"disable_web_page_preview,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
 	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	Quote                    *string         `json:"quote,omitempty"`
+	QuoteParseMode           *string         `json:"quote_parse_mode,omitempty"`
+	QuoteEntities            []MessageEntity `json:"quote_entities,omitempty"`
+	QuotePosition            *int            `json:"quote_position,omitempty"`
+}
+
+// LinkPreviewOptions configures the link preview of a message. It replaces
+// DisableWebPagePreview, which remains for servers predating it.
+type LinkPreviewOptions struct {
+	IsDisabled       *bool   `json:"is_disabled,omitempty"`
+	Url              *string `json:"url,omitempty"`
+	PreferSmallMedia *bool   `json:"prefer_small_media,omitempty"`
+	PreferLargeMedia *bool   `json:"prefer_large_media,omitempty"`
+	ShowAboveText    *bool   `json:"show_above_text,omitempty"`
 }
 
 func (c *BotClient) ForwardMessage(ctx context.Context, options ForwardMessageOptions) (*Message, error) {
@@ -97,8 +154,10 @@ func (c *BotClient) ForwardMessage(ctx context.Context, options ForwardMessageOp
 
 type ForwardMessageOptions struct {
 	ChatId              *int  `json:"chat_id,omitempty"`
+	MessageThreadId     *int  `json:"message_thread_id,omitempty"`
 	FromChatId          *int  `json:"from_chat_id,omitempty"`
 	DisableNotification *bool `json:"disable_notification,omitempty"`
+	ProtectContent      *bool `json:"protect_content,omitempty"`
 	MessageId           *int  `json:"message_id,omitempty"`
 }
 
@@ -109,16 +168,19 @@ func (c *BotClient) CopyMessage(ctx context.Context, options CopyMessageOptions)
 }
 
 type CopyMessageOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	FromChatId               *int            `json:"from_chat_id,omitempty"`
-	MessageId                *int            `json:"message_id,omitempty"`
-	Caption                  *string         `json:"caption,omitempty"`
-	ParseMode                *string         `json:"parse_mode,omitempty"`
-	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	FromChatId               *int             `json:"from_chat_id,omitempty"`
+	MessageId                *int             `json:"message_id,omitempty"`
+	Caption                  *string          `json:"caption,omitempty"`
+	ParseMode                *string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity  `json:"caption_entities,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) EditMessageText(ctx context.Context, options EditMessageTextOptions) (*Message, error) {
@@ -135,6 +197,7 @@ type EditMessageTextOptions struct {
 	ParseMode             *string                      `json:"parse_mode,omitempty"`
 	Entities              []MessageEntity              `json:"entities,omitempty"`
 	DisableWebPagePreview *bool                        `json:"disable_web_page_preview,omitempty"`
+	LinkPreviewOptions    *LinkPreviewOptions          `json:"link_preview_options,omitempty"`
 	ReplyMarkup           *InlineKeyboardMarkupOptions `json:"reply_markup,omitempty"`
 }
 
@@ -198,14 +261,17 @@ func (c *BotClient) SendContact(ctx context.Context, options SendContactOptions)
 }
 
 type SendContactOptions struct {
-	ChatId                   *int     `json:"chat_id"`
-	PhoneNumber              *string  `json:"phone_number"`
-	FirstName                *string  `json:"first_name"`
-	LastName                 *string  `json:"last_name,omitempty"`
-	Vcard                    []string `json:"vcard,omitempty"`
-	DisableNotification      *bool    `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int     `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool    `json:"allow_sending_without_reply,omitempty"`
+	ChatId                   *int             `json:"chat_id"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	PhoneNumber              *string          `json:"phone_number"`
+	FirstName                *string          `json:"first_name"`
+	LastName                 *string          `json:"last_name,omitempty"`
+	Vcard                    []string         `json:"vcard,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
 }
 
 type Poll struct {
@@ -242,23 +308,26 @@ func (c *BotClient) SendPoll(ctx context.Context, options SendPollOptions) (*Mes
 }
 
 type SendPollOptions struct {
-	ChatId                   *int            `json:"chat_id,omitempty"`
-	Question                 *string         `json:"question,omitempty"`
-	Options                  []string        `json:"options,omitempty"`
-	IsAnonymous              *bool           `json:"is_anonymous,omitempty"`
-	Type                     *string         `json:"type,omitempty"`
-	AllowsMultipleAnswers    *bool           `json:"allows_multiple_answers,omitempty"`
-	CorrectOptionId          *int            `json:"correct_option_id,omitempty"`
-	Explanation              *string         `json:"explanation,omitempty"`
-	ExplanationParseMode     *string         `json:"explanation_parse_mode,omitempty"`
-	ExplanationEntities      []MessageEntity `json:"explanation_entities,omitempty"`
-	OpenPeriod               *int            `json:"open_period,omitempty"`
-	CloseDate                *int            `json:"close_date,omitempty"`
-	IsClosed                 *bool           `json:"is_closed,omitempty"`
-	DisableNotification      *bool           `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int            `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool           `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{}     `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Question                 *string          `json:"question,omitempty"`
+	Options                  []string         `json:"options,omitempty"`
+	IsAnonymous              *bool            `json:"is_anonymous,omitempty"`
+	Type                     *string          `json:"type,omitempty"`
+	AllowsMultipleAnswers    *bool            `json:"allows_multiple_answers,omitempty"`
+	CorrectOptionId          *int             `json:"correct_option_id,omitempty"`
+	Explanation              *string          `json:"explanation,omitempty"`
+	ExplanationParseMode     *string          `json:"explanation_parse_mode,omitempty"`
+	ExplanationEntities      []MessageEntity  `json:"explanation_entities,omitempty"`
+	OpenPeriod               *int             `json:"open_period,omitempty"`
+	CloseDate                *int             `json:"close_date,omitempty"`
+	IsClosed                 *bool            `json:"is_closed,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
 
 func (c *BotClient) StopPoll(ctx context.Context, options StopPollOptions) (*Poll, error) {
@@ -285,10 +354,13 @@ func (c *BotClient) SendDice(ctx context.Context, options SendDiceOptions) (*Mes
 }
 
 type SendDiceOptions struct {
-	ChatId                   *int        `json:"chat_id,omitempty"`
-	Emoji                    *string     `json:"emoji,omitempty"`
-	DisableNotification      *bool       `json:"disable_notification,omitempty"`
-	ReplyToMessageId         *int        `json:"reply_to_message_id,omitempty"`
-	AllowSendingWithoutReply *bool       `json:"allow_sending_without_reply,omitempty"`
-	ReplyMarkup              interface{} `json:"reply_markup,omitempty"`
+	ChatId                   *int             `json:"chat_id,omitempty"`
+	MessageThreadId          *int             `json:"message_thread_id,omitempty"`
+	Emoji                    *string          `json:"emoji,omitempty"`
+	DisableNotification      *bool            `json:"disable_notification,omitempty"`
+	ProtectContent           *bool            `json:"protect_content,omitempty"`
+	ReplyToMessageId         *int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply *bool            `json:"allow_sending_without_reply,omitempty"`
+	ReplyParameters          *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup              interface{}      `json:"reply_markup,omitempty"`
 }
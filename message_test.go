@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBotClient_SendMessage(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var v map[string]interface{}
+		testBody(t, r, &v)
+		want := map[string]interface{}{
+			"chat_id": float64(1),
+			"text":    "hello",
+			"reply_parameters": map[string]interface{}{
+				"message_id":     float64(10),
+				"quote":          "quoted",
+				"quote_position": float64(4),
+			},
+			"link_preview_options": map[string]interface{}{
+				"url":                "https://example.com",
+				"prefer_small_media": true,
+			},
+		}
+		if !reflect.DeepEqual(v, want) {
+			t.Errorf("Request body is %v; want %v", v, want)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": {"message_id": 11, "text": "hello"}}`)
+	})
+
+	message, err := b.SendMessage(context.Background(), SendMessageOptions{
+		ChatId: Int(1),
+		Text:   String("hello"),
+		ReplyParameters: &ReplyParameters{
+			MessageId:     Int(10),
+			Quote:         String("quoted"),
+			QuotePosition: Int(4),
+		},
+		LinkPreviewOptions: &LinkPreviewOptions{
+			Url:              String("https://example.com"),
+			PreferSmallMedia: Bool(true),
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendMessage returned error %v", err)
+	}
+	if got, want := message.MessageId, 11; got != want {
+		t.Errorf("SendMessage message id is %d; want %d", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+// Package metrics exposes Prometheus metrics about the Bot API calls made by
+// a telegram.BotClient and the updates handled by a telegram.Router.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	telegram "github.com/ccl17"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector holds the metrics. Register it with a prometheus.Registerer, then
+// add Interceptor to the clients and Middleware to the routers to observe.
+type Collector struct {
+	apiRequests     *prometheus.CounterVec
+	apiDuration     *prometheus.HistogramVec
+	apiRateLimited  *prometheus.CounterVec
+	apiRetryAfter   *prometheus.HistogramVec
+	updatesReceived *prometheus.CounterVec
+	updatesHandled  *prometheus.CounterVec
+	updatesFailed   *prometheus.CounterVec
+	updateDuration  *prometheus.HistogramVec
+}
+
+// NewCollector creates the metrics, prefixed with namespace if not empty.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "api_requests_total",
+			Help:      "Bot API requests by method and result code.",
+		}, []string{"method", "code"}),
+		apiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of Bot API requests by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		apiRateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "api_rate_limited_total",
+			Help:      "Bot API requests rejected with 429 Too Many Requests, by method.",
+		}, []string{"method"}),
+		apiRetryAfter: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "api_retry_after_seconds",
+			Help:      "Retry after delays requested by the Bot API, by method.",
+			Buckets:   []float64{1, 2, 5, 10, 30, 60, 300, 900, 3600},
+		}, []string{"method"}),
+		updatesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "updates_received_total",
+			Help:      "Updates received by type.",
+		}, []string{"type"}),
+		updatesHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "updates_handled_total",
+			Help:      "Updates handled successfully by type.",
+		}, []string{"type"}),
+		updatesFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "updates_failed_total",
+			Help:      "Updates whose handler returned an error, by type.",
+		}, []string{"type"}),
+		updateDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "telegram",
+			Name:      "update_duration_seconds",
+			Help:      "Duration of update handling by type.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"type"}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.apiRequests,
+		c.apiDuration,
+		c.apiRateLimited,
+		c.apiRetryAfter,
+		c.updatesReceived,
+		c.updatesHandled,
+		c.updatesFailed,
+		c.updateDuration,
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// Interceptor observes the calls of a client, see telegram.BotClient.Use.
+func (c *Collector) Interceptor() telegram.Interceptor {
+	return func(ctx context.Context, call *telegram.ApiCall, invoke telegram.Invoker) error {
+		err := invoke(ctx, call)
+
+		code := "ok"
+		var apiErr *telegram.ApiError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.OriginalResponse != nil:
+			code = strconv.Itoa(apiErr.OriginalResponse.ErrorCode)
+			if apiErr.OriginalResponse.ErrorCode == 429 {
+				c.apiRateLimited.WithLabelValues(call.Method).Inc()
+			}
+			if p := apiErr.OriginalResponse.Parameters; p != nil && p.RetryAfter > 0 {
+				c.apiRetryAfter.WithLabelValues(call.Method).Observe(float64(p.RetryAfter))
+			}
+		case err != nil:
+			code = "error"
+		}
+
+		c.apiRequests.WithLabelValues(call.Method, code).Inc()
+		c.apiDuration.WithLabelValues(call.Method).Observe(call.Duration.Seconds())
+		return err
+	}
+}
+
+// Middleware observes the updates handled, see telegram.Router.Use.
+func (c *Collector) Middleware() telegram.Middleware {
+	return func(next telegram.Handler) telegram.Handler {
+		return telegram.HandlerFunc(func(ctx context.Context, update *telegram.Update) error {
+			updateType := update.Type()
+			if updateType == "" {
+				updateType = "unknown"
+			}
+			c.updatesReceived.WithLabelValues(updateType).Inc()
+
+			start := time.Now()
+			err := next.HandleUpdate(ctx, update)
+			c.updateDuration.WithLabelValues(updateType).Observe(time.Since(start).Seconds())
+
+			if err != nil {
+				c.updatesFailed.WithLabelValues(updateType).Inc()
+			} else {
+				c.updatesHandled.WithLabelValues(updateType).Inc()
+			}
+			return err
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	telegram "github.com/ccl17"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector_Interceptor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error_code": 429, "description": "Too Many Requests", "parameters": {"retry_after": 7}}`)
+	}))
+	defer server.Close()
+
+	c := NewCollector("bot")
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(c); err != nil {
+		t.Fatalf("Register returned error %v", err)
+	}
+
+	client, _ := telegram.NewBotClient("123:secret", nil, "")
+	client.BaseURL = server.URL
+	client.Use(c.Interceptor())
+
+	client.SendMessage(context.Background(), telegram.SendMessageOptions{ChatId: telegram.Int(1), Text: telegram.String("hi")})
+
+	if got := testutil.ToFloat64(c.apiRequests.WithLabelValues("sendMessage", "429")); got != 1 {
+		t.Errorf("api_requests_total is %v; want 1", got)
+	}
+	if got := testutil.ToFloat64(c.apiRateLimited.WithLabelValues("sendMessage")); got != 1 {
+		t.Errorf("api_rate_limited_total is %v; want 1", got)
+	}
+	if got := testutil.CollectAndCount(c.apiRetryAfter); got != 1 {
+		t.Errorf("api_retry_after_seconds has %d series; want 1", got)
+	}
+}
+
+func TestCollector_Middleware(t *testing.T) {
+	c := NewCollector("")
+	r := telegram.NewRouter()
+	r.Use(c.Middleware())
+	r.Message(telegram.HandlerFunc(func(ctx context.Context, update *telegram.Update) error {
+		if update.Message.Text == "fail" {
+			return errors.New("failed")
+		}
+		return nil
+	}))
+
+	for _, text := range []string{"ok", "fail", "ok"} {
+		r.HandleUpdate(context.Background(), &telegram.Update{Message: &telegram.Message{Text: text}})
+	}
+
+	if got := testutil.ToFloat64(c.updatesReceived.WithLabelValues("message")); got != 3 {
+		t.Errorf("updates_received_total is %v; want 3", got)
+	}
+	if got := testutil.ToFloat64(c.updatesHandled.WithLabelValues("message")); got != 2 {
+		t.Errorf("updates_handled_total is %v; want 2", got)
+	}
+	if got := testutil.ToFloat64(c.updatesFailed.WithLabelValues("message")); got != 1 {
+		t.Errorf("updates_failed_total is %v; want 1", got)
+	}
+}
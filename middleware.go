@@ -0,0 +1,117 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// PanicError is returned by the Recover middleware when a handler panics.
+type PanicError struct {
+	UpdateId int
+	Value    interface{}
+	Stack    []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic handling update %d: %v", e.UpdateId, e.Value)
+}
+
+// Recover turns panics of the handler into a *PanicError, so that a failing
+// handler does not stop the processing of other updates.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &PanicError{UpdateId: update.UpdateId, Value: r, Stack: debug.Stack()}
+				}
+			}()
+			return next.HandleUpdate(ctx, update)
+		})
+	}
+}
+
+// Logger logs every update with its type, chat, user, the time it took to
+// handle and the error returned, if any.
+func Logger(logger *log.Logger) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) error {
+			start := time.Now()
+			err := next.HandleUpdate(ctx, update)
+
+			var chatId, userId int
+			if chat := update.EffectiveChat(); chat != nil {
+				chatId = chat.Id
+			}
+			if user := update.EffectiveUser(); user != nil {
+				userId = user.Id
+			}
+			if err != nil {
+				logger.Printf("update %d %s chat=%d user=%d took %s: %v", update.UpdateId, update.Type(), chatId, userId, time.Since(start), err)
+			} else {
+				logger.Printf("update %d %s chat=%d user=%d took %s", update.UpdateId, update.Type(), chatId, userId, time.Since(start))
+			}
+			return err
+		})
+	}
+}
+
+// Timing calls observe with the time taken to handle every update.
+func Timing(observe func(update *Update, duration time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) error {
+			start := time.Now()
+			err := next.HandleUpdate(ctx, update)
+			observe(update, time.Since(start), err)
+			return err
+		})
+	}
+}
+
+// Timeout cancels the context of the handler after timeout.
+func Timeout(timeout time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next.HandleUpdate(ctx, update)
+		})
+	}
+}
+
+// AllowUsers drops the updates of users other than those listed.
+func AllowUsers(userIds ...int) Middleware {
+	allowed := make(map[int]bool, len(userIds))
+	for _, id := range userIds {
+		allowed[id] = true
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) error {
+			if user := update.EffectiveUser(); user == nil || !allowed[user.Id] {
+				return nil
+			}
+			return next.HandleUpdate(ctx, update)
+		})
+	}
+}
+
+// DenyUsers drops the updates of the users listed.
+func DenyUsers(userIds ...int) Middleware {
+	denied := make(map[int]bool, len(userIds))
+	for _, id := range userIds {
+		denied[id] = true
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) error {
+			if user := update.EffectiveUser(); user != nil && denied[user.Id] {
+				return nil
+			}
+			return next.HandleUpdate(ctx, update)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func recordMiddleware(name string, calls *[]string) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, update *Update) error {
+			*calls = append(*calls, name)
+			return next.HandleUpdate(ctx, update)
+		})
+	}
+}
+
+func TestRouter_Middlewares(t *testing.T) {
+	var calls []string
+	r := NewRouter()
+	r.Use(recordMiddleware("global", &calls))
+
+	admins := r.Group(UpdateTypeFilter(UpdateTypeMessage))
+	admins.Use(AllowUsers(1), recordMiddleware("group", &calls))
+	admins.Handle(nil, HandlerFunc(func(ctx context.Context, update *Update) error {
+		calls = append(calls, "admin")
+		return nil
+	}), recordMiddleware("route", &calls))
+
+	r.Message(HandlerFunc(func(ctx context.Context, update *Update) error {
+		calls = append(calls, "message")
+		return nil
+	}))
+
+	r.HandleUpdate(context.Background(), &Update{Message: &Message{From: &User{Id: 1}}})
+	if want := []string{"global", "group", "route", "admin"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls are %v; want %v", calls, want)
+	}
+
+	calls = nil
+	r.HandleUpdate(context.Background(), &Update{Message: &Message{From: &User{Id: 2}}})
+	if want := []string{"global"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls are %v; want %v", calls, want)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	r := NewRouter()
+	r.Use(Recover())
+	r.Message(HandlerFunc(func(ctx context.Context, update *Update) error {
+		panic("boom")
+	}))
+
+	err := r.HandleUpdate(context.Background(), &Update{UpdateId: 7, Message: &Message{}})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("HandleUpdate err is %v; want *PanicError", err)
+	}
+	if panicErr.UpdateId != 7 || panicErr.Value != "boom" {
+		t.Errorf("PanicError is %+v; want update 7 and value boom", panicErr)
+	}
+}
@@ -0,0 +1,296 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// OffsetState is the persisted progress of update handling. Offset is the
+// lowest update id not yet handled, all lower ids have been handled. Processed
+// holds the most recently handled ids, to skip updates delivered again.
+type OffsetState struct {
+	Offset    int   `json:"offset"`
+	Processed []int `json:"processed,omitempty"`
+}
+
+type OffsetStore interface {
+	// LoadOffset returns an empty state if nothing was saved yet.
+	LoadOffset(ctx context.Context) (*OffsetState, error)
+	SaveOffset(ctx context.Context, state *OffsetState) error
+}
+
+// MaxUpdateAttempts is the number of times an update is handled before it is
+// dropped if its handler keeps failing.
+const MaxUpdateAttempts = 3
+
+// UpdateTracker records which updates have been handled, so that an update is
+// handled at most once even if it is delivered again after a crash or by a
+// webhook retry, and no update is acknowledged before it has been handled.
+type UpdateTracker struct {
+	mu        sync.Mutex
+	store     OffsetStore
+	window    int
+	offset    int
+	maxSeen   int
+	pending   map[int]bool
+	failed    map[int]int
+	processed map[int]bool
+	order     []int
+	version   int
+	// progress is closed and replaced whenever an update has been handled or
+	// has failed.
+	progress chan struct{}
+
+	// saveMu serializes saves, savedVersion is the version last saved.
+	saveMu       sync.Mutex
+	savedVersion int
+}
+
+// NewUpdateTracker creates a tracker resuming from the state in store,
+// remembering the last window handled update ids.
+func NewUpdateTracker(ctx context.Context, store OffsetStore, window int) (*UpdateTracker, error) {
+	state, err := store.LoadOffset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if window < 1 {
+		window = 1000
+	}
+
+	t := &UpdateTracker{
+		store:     store,
+		window:    window,
+		offset:    state.Offset,
+		maxSeen:   state.Offset - 1,
+		pending:   make(map[int]bool),
+		failed:    make(map[int]int),
+		processed: make(map[int]bool),
+		progress:  make(chan struct{}),
+	}
+	for _, id := range state.Processed {
+		t.markProcessed(id)
+		if id > t.maxSeen {
+			t.maxSeen = id
+		}
+	}
+	return t, nil
+}
+
+// Offset returns the offset to resume from after a restart: the lowest update
+// id received but not yet handled, or the next id if all have been handled.
+func (t *UpdateTracker) Offset() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.offset
+}
+
+// progressed returns a channel closed once an update has been handled or has
+// failed after the call.
+func (t *UpdateTracker) progressed() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.progress
+}
+
+// Receive registers an update that will be handled later, and reports whether
+// it is new. Updates already received or handled must not be handled again.
+func (t *UpdateTracker) Receive(id int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.pending[id]; ok || t.processed[id] {
+		return false
+	}
+	t.pending[id] = false
+	if id > t.maxSeen {
+		t.maxSeen = id
+	}
+	return true
+}
+
+// Wrap returns a handler skipping updates that have already been handled or
+// are being handled, and recording the updates handled by h. An update whose
+// handler fails holds back the offset, so that it is delivered and handled
+// again, until it has failed MaxUpdateAttempts times and is dropped.
+func (t *UpdateTracker) Wrap(h Handler) Handler {
+	return HandlerFunc(func(ctx context.Context, update *Update) error {
+		if !t.start(update.UpdateId) {
+			return nil
+		}
+
+		if err := h.HandleUpdate(ctx, update); err != nil {
+			if t.fail(update.UpdateId) {
+				// The update is dropped, a failed save is retried with the
+				// next one.
+				t.save(ctx)
+			}
+			return err
+		}
+		t.done(update.UpdateId)
+		return t.save(ctx)
+	})
+}
+
+func (t *UpdateTracker) start(id int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pending[id] || t.processed[id] {
+		return false
+	}
+	t.pending[id] = true
+	if id > t.maxSeen {
+		t.maxSeen = id
+	}
+	return true
+}
+
+// fail records a failed attempt at handling an update, and reports whether the
+// update has been dropped.
+func (t *UpdateTracker) fail(id int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pending, id)
+	t.failed[id]++
+	if t.failed[id] < MaxUpdateAttempts {
+		t.advance()
+		return false
+	}
+	delete(t.failed, id)
+	t.markProcessed(id)
+	t.version++
+	t.advance()
+	return true
+}
+
+func (t *UpdateTracker) done(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pending, id)
+	delete(t.failed, id)
+	t.markProcessed(id)
+	t.version++
+	t.advance()
+}
+
+// advance moves the offset up to the lowest update id received, being handled
+// or failed, and wakes up those waiting for progress.
+func (t *UpdateTracker) advance() {
+	offset := t.maxSeen + 1
+	for pending := range t.pending {
+		if pending < offset {
+			offset = pending
+		}
+	}
+	for failed := range t.failed {
+		if failed < offset {
+			offset = failed
+		}
+	}
+	if offset > t.offset {
+		t.offset = offset
+	}
+	close(t.progress)
+	t.progress = make(chan struct{})
+}
+
+// save saves a snapshot of the state without holding mu, so that handlers are
+// not blocked by the store. Saves are serialized, and a save finding that a
+// previous one already covered its changes returns immediately.
+func (t *UpdateTracker) save(ctx context.Context) error {
+	t.saveMu.Lock()
+	defer t.saveMu.Unlock()
+
+	t.mu.Lock()
+	version := t.version
+	state := &OffsetState{Offset: t.offset, Processed: append([]int(nil), t.order...)}
+	t.mu.Unlock()
+
+	if version == t.savedVersion {
+		return nil
+	}
+	if err := t.store.SaveOffset(ctx, state); err != nil {
+		return err
+	}
+	t.savedVersion = version
+	return nil
+}
+
+func (t *UpdateTracker) markProcessed(id int) {
+	if t.processed[id] {
+		return
+	}
+	t.processed[id] = true
+	t.order = append(t.order, id)
+	if len(t.order) > t.window {
+		delete(t.processed, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+// MemoryOffsetStore is an OffsetStore keeping the state in memory.
+type MemoryOffsetStore struct {
+	mu    sync.Mutex
+	state OffsetState
+}
+
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{}
+}
+
+func (s *MemoryOffsetStore) LoadOffset(_ context.Context) (*OffsetState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state
+	state.Processed = append([]int(nil), s.state.Processed...)
+	return &state, nil
+}
+
+func (s *MemoryOffsetStore) SaveOffset(_ context.Context, state *OffsetState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = *state
+	s.state.Processed = append([]int(nil), state.Processed...)
+	return nil
+}
+
+// FileOffsetStore is an OffsetStore keeping the state in a JSON file.
+type FileOffsetStore struct {
+	path string
+}
+
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{path: path}
+}
+
+func (s *FileOffsetStore) LoadOffset(_ context.Context) (*OffsetState, error) {
+	var state OffsetState
+	b, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return &state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *FileOffsetStore) SaveOffset(_ context.Context, state *OffsetState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, b)
+}
@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateTracker(t *testing.T) {
+	store := NewFileOffsetStore(filepath.Join(t.TempDir(), "offset.json"))
+	tracker, err := NewUpdateTracker(context.Background(), store, 10)
+	if err != nil {
+		t.Fatalf("NewUpdateTracker returned error %v", err)
+	}
+
+	var handled []int
+	h := tracker.Wrap(HandlerFunc(func(ctx context.Context, update *Update) error {
+		handled = append(handled, update.UpdateId)
+		return nil
+	}))
+
+	for _, id := range []int{10, 11, 12} {
+		if !tracker.Receive(id) {
+			t.Errorf("Receive(%d) is false; want true", id)
+		}
+	}
+	if tracker.Receive(11) {
+		t.Errorf("Receive(11) is true for a received update; want false")
+	}
+
+	// Handled out of order: the offset must not move past update 10.
+	for _, id := range []int{11, 12} {
+		h.HandleUpdate(context.Background(), &Update{UpdateId: id})
+	}
+	if got, want := tracker.Offset(), 10; got != want {
+		t.Errorf("Offset is %d; want %d", got, want)
+	}
+
+	// After a restart, the updates already handled are skipped.
+	tracker, err = NewUpdateTracker(context.Background(), store, 10)
+	if err != nil {
+		t.Fatalf("NewUpdateTracker returned error %v", err)
+	}
+	h = tracker.Wrap(HandlerFunc(func(ctx context.Context, update *Update) error {
+		handled = append(handled, update.UpdateId)
+		return nil
+	}))
+	for _, id := range []int{10, 11, 12, 10} {
+		h.HandleUpdate(context.Background(), &Update{UpdateId: id})
+	}
+
+	if want := []int{11, 12, 10}; !reflect.DeepEqual(handled, want) {
+		t.Errorf("handled %v; want %v", handled, want)
+	}
+	if got, want := tracker.Offset(), 13; got != want {
+		t.Errorf("Offset is %d; want %d", got, want)
+	}
+}
+
+func TestUpdateTracker_Failed(t *testing.T) {
+	store := NewMemoryOffsetStore()
+	tracker, err := NewUpdateTracker(context.Background(), store, 10)
+	if err != nil {
+		t.Fatalf("NewUpdateTracker returned error %v", err)
+	}
+
+	fail := true
+	attempts := 0
+	h := tracker.Wrap(HandlerFunc(func(ctx context.Context, update *Update) error {
+		attempts++
+		if fail {
+			return errors.New("failed")
+		}
+		return nil
+	}))
+
+	if err := h.HandleUpdate(context.Background(), &Update{UpdateId: 5}); err == nil {
+		t.Fatalf("HandleUpdate err is nil; want handler error")
+	}
+	if got := tracker.Offset(); got != 5 {
+		t.Errorf("Offset after failure is %d; want 5", got)
+	}
+	if state, _ := store.LoadOffset(context.Background()); len(state.Processed) != 0 {
+		t.Errorf("saved processed %v after failure; want none", state.Processed)
+	}
+
+	// Delivered again, the update is handled again.
+	fail = false
+	if err := h.HandleUpdate(context.Background(), &Update{UpdateId: 5}); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("handler called %d times; want 2", attempts)
+	}
+	if state, _ := store.LoadOffset(context.Background()); state.Offset != 6 {
+		t.Errorf("saved offset is %d; want 6", state.Offset)
+	}
+
+	// A failed update holds back the offset until it has failed
+	// MaxUpdateAttempts times.
+	h = tracker.Wrap(HandlerFunc(func(ctx context.Context, update *Update) error {
+		if update.UpdateId == 6 {
+			return errors.New("failed")
+		}
+		return nil
+	}))
+	tracker.Receive(6)
+	h.HandleUpdate(context.Background(), &Update{UpdateId: 7})
+	for i := 0; i < MaxUpdateAttempts; i++ {
+		if got := tracker.Offset(); got != 6 {
+			t.Errorf("Offset after %d failures of update 6 is %d; want 6", i, got)
+		}
+		h.HandleUpdate(context.Background(), &Update{UpdateId: 6})
+	}
+	if got := tracker.Offset(); got != 8 {
+		t.Errorf("Offset after dropping update 6 is %d; want 8", got)
+	}
+}
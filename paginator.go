@@ -0,0 +1,198 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+)
+
+const paginatorNoop = "-"
+
+// paginatorData is the callback data of a navigation button. Count is the page
+// count when the button was built, so that the index is checked before the
+// page is requested.
+type paginatorData struct {
+	Index int
+	Count int
+	Key   string
+}
+
+// PaginatorPage is a single page rendered by a Paginator.
+type PaginatorPage struct {
+	Text      string
+	Entities  []MessageEntity
+	ParseMode string
+	// Buttons are shown above the navigation row, e.g. one button per item.
+	Buttons   [][]InlineKeyboardButtonOptions
+	PageCount int
+}
+
+// PageFunc returns the page with the given zero based index for the list
+// identified by key.
+type PageFunc func(ctx context.Context, key string, page int) (*PaginatorPage, error)
+
+// Paginator shows a list one page at a time in a single message, with
+// navigation buttons that edit the message in place.
+type Paginator struct {
+	client *BotClient
+	codec  *CallbackDataCodec
+	prefix string
+	pages  PageFunc
+
+	PreviousText string
+	NextText     string
+	// ExpiredText is shown to the user when the message can no longer be edited.
+	ExpiredText string
+}
+
+// NewPaginator creates a paginator whose callback data starts with prefix and
+// is signed by codec. The key passed to Send is carried in the callback data,
+// so it must be short unless the codec has a store.
+func NewPaginator(client *BotClient, codec *CallbackDataCodec, prefix string, pages PageFunc) *Paginator {
+	return &Paginator{
+		client:       client,
+		codec:        codec,
+		prefix:       prefix,
+		pages:        pages,
+		PreviousText: "« Prev",
+		NextText:     "Next »",
+		ExpiredText:  "This menu has expired.",
+	}
+}
+
+// Register routes the callback queries of the paginator to it.
+func (p *Paginator) Register(r *Router) {
+	r.CallbackQueryPrefix(p.prefix+":", p)
+}
+
+// Send sends the first page of the list identified by key to a chat.
+func (p *Paginator) Send(ctx context.Context, chatId int, key string) (*Message, error) {
+	page, err := p.pages(ctx, key, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	markup, err := p.Markup(ctx, key, 0, page)
+	if err != nil {
+		return nil, err
+	}
+
+	options := SendMessageOptions{
+		ChatId:      Int(chatId),
+		Text:        String(page.Text),
+		Entities:    page.Entities,
+		ReplyMarkup: markup,
+	}
+	if page.ParseMode != "" {
+		options.ParseMode = String(page.ParseMode)
+	}
+	return p.client.SendMessage(ctx, options)
+}
+
+// Markup returns the keyboard of a page, made of its buttons followed by the
+// navigation row.
+func (p *Paginator) Markup(ctx context.Context, key string, index int, page *PaginatorPage) (*InlineKeyboardMarkupOptions, error) {
+	keyboard := NewInlineKeyboard()
+	for _, row := range page.Buttons {
+		keyboard.Row(row...)
+	}
+
+	if page.PageCount > 1 {
+		var navigation []InlineKeyboardButtonOptions
+		if index > 0 {
+			data, err := p.codec.Encode(ctx, p.prefix, paginatorData{Index: index - 1, Count: page.PageCount, Key: key})
+			if err != nil {
+				return nil, err
+			}
+			navigation = append(navigation, CallbackButton(p.PreviousText, data))
+		}
+		navigation = append(navigation, CallbackButton(fmt.Sprintf("%d/%d", index+1, page.PageCount), p.prefix+":"+paginatorNoop))
+		if index < page.PageCount-1 {
+			data, err := p.codec.Encode(ctx, p.prefix, paginatorData{Index: index + 1, Count: page.PageCount, Key: key})
+			if err != nil {
+				return nil, err
+			}
+			navigation = append(navigation, CallbackButton(p.NextText, data))
+		}
+		keyboard.Row(navigation...)
+	}
+	return keyboard.Build()
+}
+
+// HandleUpdate handles a navigation callback query by editing the message to
+// show the requested page.
+func (p *Paginator) HandleUpdate(ctx context.Context, update *Update) error {
+	query := update.CallbackQuery
+	if query == nil {
+		return nil
+	}
+
+	if query.Data == p.prefix+":"+paginatorNoop {
+		return p.answer(ctx, query, "")
+	}
+
+	// Invalid data is answered too, so that the client stops waiting.
+	var data paginatorData
+	if err := p.codec.Decode(ctx, query.Data, &data); err != nil {
+		p.answer(ctx, query, p.ExpiredText)
+		return err
+	}
+	if data.Index < 0 || data.Index >= data.Count {
+		p.answer(ctx, query, p.ExpiredText)
+		return fmt.Errorf("invalid paginator page %d of %d", data.Index, data.Count)
+	}
+	index, key := data.Index, data.Key
+
+	page, err := p.pages(ctx, key, index)
+	if err != nil {
+		return err
+	}
+	if page.PageCount > 0 && index >= page.PageCount {
+		index = page.PageCount - 1
+		if page, err = p.pages(ctx, key, index); err != nil {
+			return err
+		}
+	}
+
+	markup, err := p.Markup(ctx, key, index, page)
+	if err != nil {
+		return err
+	}
+
+	options := EditMessageTextOptions{
+		Text:        String(page.Text),
+		Entities:    page.Entities,
+		ReplyMarkup: markup,
+	}
+	if page.ParseMode != "" {
+		options.ParseMode = String(page.ParseMode)
+	}
+	if query.Message != nil {
+		options.ChatId = Int(query.Message.Chat.Id)
+		options.MessageId = Int(query.Message.MessageId)
+	} else {
+		options.InlineMessageId = String(query.InlineMessageId)
+	}
+
+	_, err = p.client.EditMessageText(ctx, options)
+	switch {
+	case IsMessageExpired(err):
+		return p.answer(ctx, query, p.ExpiredText)
+	case err != nil && !IsMessageNotModified(err):
+		return err
+	}
+	return p.answer(ctx, query, "")
+}
+
+func (p *Paginator) answer(ctx context.Context, query *CallbackQuery, text string) error {
+	options := AnswerCallbackQueryOptions{CallbackQueryId: String(query.Id)}
+	if text != "" {
+		options.Text = String(text)
+		options.ShowAlert = Bool(true)
+	}
+
+	err := p.client.AnswerCallbackQuery(ctx, options)
+	if IsQueryExpired(err) {
+		return nil
+	}
+	return err
+}
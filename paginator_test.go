@@ -0,0 +1,116 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPaginator_HandleUpdate(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	pages := func(ctx context.Context, key string, page int) (*PaginatorPage, error) {
+		return &PaginatorPage{Text: fmt.Sprintf("%s page %d", key, page), PageCount: 3}, nil
+	}
+	codec, _ := NewCallbackDataCodec([]byte("secret"), nil, 0)
+	p := NewPaginator(b, codec, "orders", pages)
+
+	var edited *EditMessageTextOptions
+	mux.HandleFunc("/editMessageText", func(w http.ResponseWriter, r *http.Request) {
+		edited = new(EditMessageTextOptions)
+		testBody(t, r, edited)
+		fmt.Fprint(w, `{"ok": false, "error_code": 400, "description": "Bad Request: message is not modified"}`)
+	})
+
+	var answered bool
+	mux.HandleFunc("/answerCallbackQuery", func(w http.ResponseWriter, r *http.Request) {
+		answered = true
+		fmt.Fprint(w, `{"ok": true, "result": true}`)
+	})
+
+	r := NewRouter()
+	p.Register(r)
+
+	data, err := codec.Encode(context.Background(), "orders", paginatorData{Index: 1, Count: 3, Key: "42"})
+	if err != nil {
+		t.Fatalf("Encode returned error %v", err)
+	}
+	update := &Update{CallbackQuery: &CallbackQuery{
+		Id:      "1",
+		Message: &Message{MessageId: 10, Chat: &Chat{Id: 20}},
+		Data:    data,
+	}}
+	if err := r.HandleUpdate(context.Background(), update); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+
+	if edited == nil {
+		t.Fatalf("HandleUpdate did not edit the message")
+	}
+	if got, want := *edited.Text, "42 page 1"; got != want {
+		t.Errorf("edited text is %q; want %q", got, want)
+	}
+	if got, want := len(edited.ReplyMarkup.InlineKeyboard[0]), 3; got != want {
+		t.Errorf("navigation row has %d buttons; want %d", got, want)
+	}
+	if !answered {
+		t.Errorf("HandleUpdate did not answer the callback query")
+	}
+}
+
+func TestPaginator_HandleUpdate_Invalid(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	var answers []string
+	mux.HandleFunc("/answerCallbackQuery", func(w http.ResponseWriter, r *http.Request) {
+		v := new(AnswerCallbackQueryOptions)
+		testBody(t, r, v)
+		text := ""
+		if v.Text != nil {
+			text = *v.Text
+		}
+		answers = append(answers, text)
+		fmt.Fprint(w, `{"ok": true, "result": true}`)
+	})
+
+	pages := func(ctx context.Context, key string, page int) (*PaginatorPage, error) {
+		t.Errorf("pages called with key %q and page %d", key, page)
+		return &PaginatorPage{PageCount: 3}, nil
+	}
+	codec, _ := NewCallbackDataCodec([]byte("secret"), nil, 0)
+	p := NewPaginator(b, codec, "orders", pages)
+
+	forged, _ := NewCallbackDataCodec([]byte("other"), nil, 0)
+	forgedData, _ := forged.Encode(context.Background(), "orders", paginatorData{Index: 1, Count: 3, Key: "42"})
+	negative, _ := codec.Encode(context.Background(), "orders", paginatorData{Index: -1, Count: 3, Key: "42"})
+	outOfRange, _ := codec.Encode(context.Background(), "orders", paginatorData{Index: 3, Count: 3, Key: "42"})
+
+	for _, data := range []string{"orders:1:42", forgedData, negative, outOfRange} {
+		update := &Update{CallbackQuery: &CallbackQuery{
+			Id:      "1",
+			Message: &Message{MessageId: 10, Chat: &Chat{Id: 20}},
+			Data:    data,
+		}}
+		if err := p.HandleUpdate(context.Background(), update); err == nil {
+			t.Errorf("HandleUpdate(%q) returned no error", data)
+		}
+	}
+
+	update := &Update{CallbackQuery: &CallbackQuery{Id: "1", Data: forgedData}}
+	if err := p.HandleUpdate(context.Background(), update); !errors.Is(err, ErrCallbackDataInvalid) {
+		t.Errorf("HandleUpdate err is %v; want %v", err, ErrCallbackDataInvalid)
+	}
+
+	if len(answers) != 5 {
+		t.Fatalf("answered %d callback queries; want 5", len(answers))
+	}
+	for _, text := range answers {
+		if text != p.ExpiredText {
+			t.Errorf("answered %q; want %q", text, p.ExpiredText)
+		}
+	}
+}
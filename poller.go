@@ -0,0 +1,107 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Poller receives updates by long polling and submits them to a WorkerPool.
+type Poller struct {
+	client  *BotClient
+	options GetUpdatesOptions
+
+	// RetryDelay is waited after GetUpdates fails, unless the server asks
+	// to retry after a given time.
+	RetryDelay time.Duration
+	// OnError is called with the errors returned by GetUpdates.
+	OnError func(err error)
+	// Tracker, if set, provides the offset so that updates are only
+	// acknowledged once handled, and filters out updates already received.
+	// The handler of the pool must then be wrapped with Tracker.Wrap.
+	Tracker *UpdateTracker
+}
+
+func NewPoller(client *BotClient, options GetUpdatesOptions) *Poller {
+	if options.Timeout == nil {
+		options.Timeout = Int(30)
+	}
+
+	return &Poller{
+		client:     client,
+		options:    options,
+		RetryDelay: 3 * time.Second,
+	}
+}
+
+// Run polls for updates until ctx is done. Submitting blocks while the queue
+// of a worker is full, so no further updates are requested until there is room.
+func (p *Poller) Run(ctx context.Context, pool *WorkerPool) error {
+	options := p.options
+	for {
+		var progress <-chan struct{}
+		if p.Tracker != nil {
+			if offset := p.Tracker.Offset(); offset > 0 {
+				options.Offset = Int(offset)
+			}
+			progress = p.Tracker.progressed()
+		}
+
+		updates, err := p.client.GetUpdates(ctx, options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if p.OnError != nil {
+				p.OnError(err)
+			}
+			if err := sleep(ctx, p.retryDelay(err)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		submitted := 0
+		for _, update := range updates {
+			if p.Tracker == nil {
+				options.Offset = Int(update.UpdateId + 1)
+			} else if !p.Tracker.Receive(update.UpdateId) {
+				continue
+			}
+			if err := pool.Submit(ctx, update); err != nil {
+				return err
+			}
+			submitted++
+		}
+
+		// Updates still being handled are returned again until acknowledged,
+		// wait for one of them to be handled instead of polling in a busy loop.
+		if len(updates) > 0 && submitted == 0 && progress != nil {
+			select {
+			case <-progress:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+func (p *Poller) retryDelay(err error) time.Duration {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) && apiErr.OriginalResponse.Parameters != nil && apiErr.OriginalResponse.Parameters.RetryAfter > 0 {
+		return time.Duration(apiErr.OriginalResponse.Parameters.RetryAfter) * time.Second
+	}
+	return p.RetryDelay
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPoller_TrackerRestart(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var offsets []int
+	mux.HandleFunc("/getUpdates", func(w http.ResponseWriter, r *http.Request) {
+		v := new(GetUpdatesOptions)
+		testBody(t, r, v)
+
+		offset := 0
+		if v.Offset != nil {
+			offset = *v.Offset
+		}
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+
+		var updates []Update
+		for _, id := range []int{1, 2} {
+			if id >= offset {
+				updates = append(updates, Update{UpdateId: id, Message: &Message{Chat: &Chat{Id: id}}})
+			}
+		}
+		result, _ := json.Marshal(updates)
+		fmt.Fprintf(w, `{"ok": true, "result": %s}`, result)
+	})
+
+	store := NewMemoryOffsetStore()
+
+	// The bot stops while update 2 is still being handled.
+	run := func(handler HandlerFunc, until <-chan struct{}) {
+		tracker, err := NewUpdateTracker(context.Background(), store, 10)
+		if err != nil {
+			t.Fatalf("NewUpdateTracker returned error %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		pool := NewWorkerPool(tracker.Wrap(handler), WorkerPoolOptions{Workers: 2})
+		pool.Start(ctx)
+		poller := NewPoller(b, GetUpdatesOptions{})
+		poller.Tracker = tracker
+
+		errs := make(chan error, 1)
+		go func() { errs <- poller.Run(ctx, pool) }()
+
+		select {
+		case <-until:
+		case <-time.After(5 * time.Second):
+			t.Errorf("updates were not handled in time")
+		}
+		cancel()
+		<-errs
+	}
+
+	handled := make(chan struct{})
+	var once sync.Once
+	run(func(ctx context.Context, update *Update) error {
+		if update.UpdateId == 2 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		once.Do(func() { close(handled) })
+		return nil
+	}, handled)
+
+	mu.Lock()
+	for _, offset := range offsets {
+		if offset > 2 {
+			t.Errorf("getUpdates called with offset %d before update 2 was handled", offset)
+		}
+	}
+	mu.Unlock()
+
+	// After the restart, update 2 is delivered again and update 1 is skipped.
+	var got []int
+	handled = make(chan struct{})
+	run(func(ctx context.Context, update *Update) error {
+		got = append(got, update.UpdateId)
+		close(handled)
+		return nil
+	}, handled)
+
+	if len(got) != 1 || got[0] != 2 {
+		t.Errorf("handled %v after the restart; want [2]", got)
+	}
+}
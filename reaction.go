@@ -0,0 +1,66 @@
+package telegram
+
+import (
+	"context"
+)
+
+const (
+	ReactionEmoji       = "emoji"
+	ReactionCustomEmoji = "custom_emoji"
+)
+
+// ReactionType is a reaction to a message, see ReactionTypeEmoji and
+// ReactionTypeCustomEmoji.
+type ReactionType struct {
+	Type          string `json:"type"`
+	Emoji         string `json:"emoji,omitempty"`
+	CustomEmojiId string `json:"custom_emoji_id,omitempty"`
+}
+
+// ReactionTypeEmoji is a reaction with one of the emoji allowed by Telegram.
+func ReactionTypeEmoji(emoji string) ReactionType {
+	return ReactionType{Type: ReactionEmoji, Emoji: emoji}
+}
+
+func ReactionTypeCustomEmoji(customEmojiId string) ReactionType {
+	return ReactionType{Type: ReactionCustomEmoji, CustomEmojiId: customEmojiId}
+}
+
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// MessageReactionUpdated is a change of the reactions of a user to a message.
+// User is nil for anonymous reactions, made on behalf of ActorChat.
+type MessageReactionUpdated struct {
+	Chat        *Chat          `json:"chat"`
+	MessageId   int            `json:"message_id"`
+	User        *User          `json:"user"`
+	ActorChat   *Chat          `json:"actor_chat"`
+	Date        int            `json:"date"`
+	OldReaction []ReactionType `json:"old_reaction"`
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// MessageReactionCountUpdated is a change of the anonymous reactions to a
+// message, such as those to channel posts.
+type MessageReactionCountUpdated struct {
+	Chat      *Chat           `json:"chat"`
+	MessageId int             `json:"message_id"`
+	Date      int             `json:"date"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+// SetMessageReaction replaces the reactions of the bot to a message. An empty
+// Reaction removes them.
+func (c *BotClient) SetMessageReaction(ctx context.Context, options SetMessageReactionOptions) error {
+	return c.postJson(ctx, apiSetMessageReaction, options, nil)
+}
+
+type SetMessageReactionOptions struct {
+	ChatId    *int           `json:"chat_id,omitempty"`
+	MessageId *int           `json:"message_id,omitempty"`
+	Reaction  []ReactionType `json:"reaction,omitempty"`
+	IsBig     *bool          `json:"is_big,omitempty"`
+}
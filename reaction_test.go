@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBotClient_SetMessageReaction(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := SetMessageReactionOptions{ChatId: Int(7), MessageId: Int(3), Reaction: []ReactionType{ReactionTypeEmoji("👍")}}
+
+	mux.HandleFunc("/setMessageReaction", func(w http.ResponseWriter, r *http.Request) {
+		v := new(SetMessageReactionOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": true}`)
+	})
+
+	if err := b.SetMessageReaction(context.Background(), opts); err != nil {
+		t.Errorf("SetMessageReaction returned error %v", err)
+	}
+}
+
+func TestRouter_MessageReaction(t *testing.T) {
+	var update Update
+	err := json.Unmarshal([]byte(`{
+		"update_id": 1,
+		"message_reaction": {
+			"chat": {"id": -100, "type": "supergroup"},
+			"message_id": 3,
+			"user": {"id": 7},
+			"date": 1700000000,
+			"old_reaction": [],
+			"new_reaction": [{"type": "custom_emoji", "custom_emoji_id": "123"}]
+		}
+	}`), &update)
+	if err != nil {
+		t.Fatalf("Unmarshal returned error %v", err)
+	}
+
+	var got []ReactionType
+	r := NewRouter()
+	r.MessageReactionCount(HandlerFunc(func(ctx context.Context, update *Update) error {
+		t.Errorf("message_reaction update routed to MessageReactionCount")
+		return nil
+	}))
+	r.MessageReaction(HandlerFunc(func(ctx context.Context, update *Update) error {
+		got = update.MessageReaction.NewReaction
+		return nil
+	}))
+	if err := r.HandleUpdate(context.Background(), &update); err != nil {
+		t.Fatalf("HandleUpdate returned error %v", err)
+	}
+
+	if want := []ReactionType{ReactionTypeCustomEmoji("123")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewReaction is %+v; want %+v", got, want)
+	}
+	if user := update.EffectiveUser(); user == nil || user.Id != 7 {
+		t.Errorf("EffectiveUser is %+v; want user 7", user)
+	}
+}
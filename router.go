@@ -0,0 +1,192 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+)
+
+// Handler handles a single update.
+type Handler interface {
+	HandleUpdate(ctx context.Context, update *Update) error
+}
+
+type HandlerFunc func(ctx context.Context, update *Update) error
+
+func (f HandlerFunc) HandleUpdate(ctx context.Context, update *Update) error {
+	return f(ctx, update)
+}
+
+// Middleware wraps a handler to add behaviour around it.
+type Middleware func(Handler) Handler
+
+func chain(middlewares []Middleware, handler Handler) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Filter reports whether a route applies to an update. It may return a
+// derived context carrying values for the handler.
+type Filter func(ctx context.Context, update *Update) (context.Context, bool)
+
+// UpdateTypeFilter matches updates of the given type, see Update.Type.
+func UpdateTypeFilter(updateType string) Filter {
+	return func(ctx context.Context, update *Update) (context.Context, bool) {
+		return ctx, update.Type() == updateType
+	}
+}
+
+// CallbackQueryPrefixFilter matches callback queries whose data starts with prefix.
+func CallbackQueryPrefixFilter(prefix string) Filter {
+	return func(ctx context.Context, update *Update) (context.Context, bool) {
+		return ctx, update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+// Router dispatches updates to the handler of the first route whose filter
+// matches. A nil filter matches every update.
+type Router struct {
+	routes      []route
+	middlewares []Middleware
+	notFound    Handler
+}
+
+type route struct {
+	filter      Filter
+	handler     Handler
+	group       *Router
+	middlewares []Middleware
+}
+
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Use adds middlewares wrapping the handling of every update by the router,
+// including updates matched by no route.
+func (r *Router) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// Handle adds a route, with middlewares wrapping its handler only.
+func (r *Router) Handle(filter Filter, handler Handler, middlewares ...Middleware) {
+	r.routes = append(r.routes, route{filter: filter, handler: handler, middlewares: middlewares})
+}
+
+func (r *Router) HandleFunc(filter Filter, handler HandlerFunc, middlewares ...Middleware) {
+	r.Handle(filter, handler, middlewares...)
+}
+
+// Group returns a router for routes sharing filter and the middlewares added
+// to the group with Use. Updates matching filter but none of the routes of
+// the group continue to the routes following the group.
+func (r *Router) Group(filter Filter) *Router {
+	group := NewRouter()
+	r.routes = append(r.routes, route{filter: filter, group: group})
+	return group
+}
+
+// NotFound sets the handler for updates matched by no route.
+func (r *Router) NotFound(handler Handler) {
+	r.notFound = handler
+}
+
+func (r *Router) Message(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeMessage), handler)
+}
+
+func (r *Router) EditedMessage(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeEditedMessage), handler)
+}
+
+func (r *Router) ChannelPost(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeChannelPost), handler)
+}
+
+func (r *Router) EditedChannelPost(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeEditedChannelPost), handler)
+}
+
+func (r *Router) InlineQuery(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeInlineQuery), handler)
+}
+
+func (r *Router) ChosenInlineResult(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeChosenInlineResult), handler)
+}
+
+func (r *Router) CallbackQuery(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeCallbackQuery), handler)
+}
+
+func (r *Router) Poll(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypePoll), handler)
+}
+
+func (r *Router) PollAnswer(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypePollAnswer), handler)
+}
+
+func (r *Router) MyChatMember(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeMyChatMember), handler)
+}
+
+func (r *Router) ChatMember(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeChatMember), handler)
+}
+
+func (r *Router) ChatJoinRequest(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeChatJoinRequest), handler)
+}
+
+func (r *Router) MessageReaction(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeMessageReaction), handler)
+}
+
+func (r *Router) MessageReactionCount(handler Handler) {
+	r.Handle(UpdateTypeFilter(UpdateTypeMessageReactionCount), handler)
+}
+
+// CallbackQueryPrefix routes callback queries whose data starts with prefix.
+func (r *Router) CallbackQueryPrefix(prefix string, handler Handler) {
+	r.Handle(CallbackQueryPrefixFilter(prefix), handler)
+}
+
+func (r *Router) HandleUpdate(ctx context.Context, update *Update) error {
+	dispatch := HandlerFunc(func(ctx context.Context, update *Update) error {
+		routeCtx, handler, ok := r.match(ctx, update)
+		if !ok {
+			if r.notFound == nil {
+				return nil
+			}
+			routeCtx, handler = ctx, r.notFound
+		}
+		return handler.HandleUpdate(routeCtx, update)
+	})
+	return chain(r.middlewares, dispatch).HandleUpdate(ctx, update)
+}
+
+// match returns the handler of the first route matching update, wrapped in
+// the middlewares of the route and of the groups it belongs to.
+func (r *Router) match(ctx context.Context, update *Update) (context.Context, Handler, bool) {
+	for _, route := range r.routes {
+		routeCtx := ctx
+		if route.filter != nil {
+			var ok bool
+			if routeCtx, ok = route.filter(ctx, update); !ok {
+				continue
+			}
+		}
+
+		if route.group != nil {
+			groupCtx, handler, ok := route.group.match(routeCtx, update)
+			if !ok {
+				continue
+			}
+			return groupCtx, chain(route.group.middlewares, handler), true
+		}
+		return routeCtx, chain(route.middlewares, route.handler), true
+	}
+	return ctx, nil, false
+}
@@ -0,0 +1,406 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// ErrSessionConflict is returned when a session was saved by someone else
+// since it was loaded.
+var ErrSessionConflict = errors.New("session was modified concurrently")
+
+// SessionStore is a key-value store with optimistic locking. Every saved value
+// gets a new version, and a save only succeeds if the stored version is still
+// the one that was loaded.
+type SessionStore interface {
+	// Load returns the value and version of key, or a nil value and version 0
+	// if the key does not exist.
+	Load(ctx context.Context, key string) ([]byte, int64, error)
+	// Save stores value if the version of key is version and returns the new
+	// version, or ErrSessionConflict.
+	Save(ctx context.Context, key string, value []byte, version int64) (int64, error)
+	// Delete removes key if its version is version, or returns
+	// ErrSessionConflict.
+	Delete(ctx context.Context, key string, version int64) error
+}
+
+// Session holds small values for a user or a chat between updates.
+type Session struct {
+	Key     string
+	values  map[string]json.RawMessage
+	version int64
+	changed bool
+}
+
+// Get decodes the value of name into v and reports whether it exists.
+func (s *Session) Get(name string, v interface{}) (bool, error) {
+	value, ok := s.values[name]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(value, v)
+}
+
+func (s *Session) Set(name string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.values[name] = value
+	s.changed = true
+	return nil
+}
+
+func (s *Session) Delete(name string) {
+	if _, ok := s.values[name]; ok {
+		delete(s.values, name)
+		s.changed = true
+	}
+}
+
+// Clear removes all values of the session.
+func (s *Session) Clear() {
+	if len(s.values) > 0 {
+		s.values = make(map[string]json.RawMessage)
+		s.changed = true
+	}
+}
+
+type sessionContextKey int
+
+const (
+	userSessionContextKey sessionContextKey = iota
+	chatSessionContextKey
+)
+
+// UserSession returns the session of the user who triggered the update being
+// handled, or nil outside of a SessionManager.
+func UserSession(ctx context.Context) *Session {
+	s, _ := ctx.Value(userSessionContextKey).(*Session)
+	return s
+}
+
+// ChatSession returns the session of the chat of the update being handled,
+// or nil outside of a SessionManager.
+func ChatSession(ctx context.Context) *Session {
+	s, _ := ctx.Value(chatSessionContextKey).(*Session)
+	return s
+}
+
+// SessionManager loads the user and chat sessions of an update before its
+// handler runs and saves them after it returns. Updates sharing a session are
+// serialized within the process, concurrent writers in other processes are
+// detected through the versions of the store.
+type SessionManager struct {
+	store SessionStore
+	locks keyedMutex
+}
+
+func NewSessionManager(store SessionStore) *SessionManager {
+	return &SessionManager{store: store}
+}
+
+func UserSessionKey(userId int) string {
+	return "user:" + strconv.Itoa(userId)
+}
+
+func ChatSessionKey(chatId int) string {
+	return "chat:" + strconv.Itoa(chatId)
+}
+
+// Wrap returns a handler running h with the sessions of the update in its context.
+func (m *SessionManager) Wrap(h Handler) Handler {
+	return HandlerFunc(func(ctx context.Context, update *Update) error {
+		var keys []string
+		if user := update.EffectiveUser(); user != nil {
+			keys = append(keys, UserSessionKey(user.Id))
+		}
+		if chat := update.EffectiveChat(); chat != nil {
+			keys = append(keys, ChatSessionKey(chat.Id))
+		}
+
+		// Lock in a fixed order so that updates sharing both keys cannot deadlock.
+		if len(keys) == 2 && keys[1] < keys[0] {
+			keys[0], keys[1] = keys[1], keys[0]
+		}
+		for _, key := range keys {
+			defer m.locks.lock(key)()
+		}
+
+		var sessions []*Session
+		if user := update.EffectiveUser(); user != nil {
+			s, err := m.Load(ctx, UserSessionKey(user.Id))
+			if err != nil {
+				return err
+			}
+			ctx = context.WithValue(ctx, userSessionContextKey, s)
+			sessions = append(sessions, s)
+		}
+		if chat := update.EffectiveChat(); chat != nil {
+			s, err := m.Load(ctx, ChatSessionKey(chat.Id))
+			if err != nil {
+				return err
+			}
+			ctx = context.WithValue(ctx, chatSessionContextKey, s)
+			sessions = append(sessions, s)
+		}
+
+		if err := h.HandleUpdate(ctx, update); err != nil {
+			return err
+		}
+
+		for _, s := range sessions {
+			if err := m.Save(ctx, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Load loads the session stored under key.
+func (m *SessionManager) Load(ctx context.Context, key string) (*Session, error) {
+	value, version, err := m.store.Load(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("load session %s: %w", key, err)
+	}
+
+	s := &Session{Key: key, values: make(map[string]json.RawMessage), version: version}
+	if value != nil {
+		if err := json.Unmarshal(value, &s.values); err != nil {
+			return nil, fmt.Errorf("decode session %s: %w", key, err)
+		}
+	}
+	return s, nil
+}
+
+// Save saves the session if it was changed since it was loaded.
+func (m *SessionManager) Save(ctx context.Context, s *Session) error {
+	if !s.changed {
+		return nil
+	}
+
+	if len(s.values) == 0 {
+		if err := m.store.Delete(ctx, s.Key, s.version); err != nil {
+			return fmt.Errorf("delete session %s: %w", s.Key, err)
+		}
+		s.version, s.changed = 0, false
+		return nil
+	}
+
+	value, err := json.Marshal(s.values)
+	if err != nil {
+		return err
+	}
+	version, err := m.store.Save(ctx, s.Key, value, s.version)
+	if err != nil {
+		return fmt.Errorf("save session %s: %w", s.Key, err)
+	}
+	s.version, s.changed = version, false
+	return nil
+}
+
+// keyedMutex serializes work on the same key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	sync.Mutex
+	refs int
+}
+
+func (k *keyedMutex) lock(key string) (unlock func()) {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyedMutexEntry)
+	}
+	entry, ok := k.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{}
+		k.locks[key] = entry
+	}
+	entry.refs++
+	k.mu.Unlock()
+
+	entry.Lock()
+	return func() {
+		entry.Unlock()
+		k.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// MemorySessionStore is a SessionStore keeping sessions in memory.
+type MemorySessionStore struct {
+	mu      sync.Mutex
+	entries map[string]memorySessionEntry
+}
+
+type memorySessionEntry struct {
+	value   []byte
+	version int64
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		entries: make(map[string]memorySessionEntry),
+	}
+}
+
+func (s *MemorySessionStore) Load(_ context.Context, key string) ([]byte, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.entries[key]
+	return entry.value, entry.version, nil
+}
+
+func (s *MemorySessionStore) Save(_ context.Context, key string, value []byte, version int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries[key].version != version {
+		return 0, ErrSessionConflict
+	}
+	s.entries[key] = memorySessionEntry{value: value, version: version + 1}
+	return version + 1, nil
+}
+
+func (s *MemorySessionStore) Delete(_ context.Context, key string, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries[key].version != version {
+		return ErrSessionConflict
+	}
+	delete(s.entries, key)
+	return nil
+}
+
+// FileSessionStore is a SessionStore keeping every session in a JSON file of
+// a directory. Versions are only checked within the process.
+type FileSessionStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+type fileSessionEntry struct {
+	Version int64           `json:"version"`
+	Value   json.RawMessage `json:"value"`
+}
+
+func NewFileSessionStore(dir string) (*FileSessionStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileSessionStore{dir: dir}, nil
+}
+
+func (s *FileSessionStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
+
+func (s *FileSessionStore) Load(_ context.Context, key string) ([]byte, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entry.Value, entry.Version, nil
+}
+
+func (s *FileSessionStore) read(key string) (*fileSessionEntry, error) {
+	var entry fileSessionEntry
+	b, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return &entry, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *FileSessionStore) Save(_ context.Context, key string, value []byte, version int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read(key)
+	if err != nil {
+		return 0, err
+	}
+	if entry.Version != version {
+		return 0, ErrSessionConflict
+	}
+
+	b, err := json.Marshal(fileSessionEntry{Version: version + 1, Value: value})
+	if err != nil {
+		return 0, err
+	}
+	if err := writeFileAtomic(s.path(key), b); err != nil {
+		return 0, err
+	}
+	return version + 1, nil
+}
+
+func (s *FileSessionStore) Delete(_ context.Context, key string, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.read(key)
+	if err != nil {
+		return err
+	}
+	if entry.Version != version {
+		return ErrSessionConflict
+	}
+
+	err = os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// writeFileAtomic replaces the file at path with data, so that readers never
+// see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
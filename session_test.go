@@ -0,0 +1,81 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSessionManager(t *testing.T) {
+	fileStore, err := NewFileSessionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileSessionStore returned error %v", err)
+	}
+
+	for _, store := range []SessionStore{NewMemorySessionStore(), fileStore} {
+		m := NewSessionManager(store)
+		update := &Update{Message: &Message{Chat: &Chat{Id: 1}, From: &User{Id: 2}}}
+
+		increment := m.Wrap(HandlerFunc(func(ctx context.Context, update *Update) error {
+			var count int
+			if _, err := UserSession(ctx).Get("count", &count); err != nil {
+				return err
+			}
+			if err := UserSession(ctx).Set("count", count+1); err != nil {
+				return err
+			}
+			return ChatSession(ctx).Set("language", "en")
+		}))
+
+		for i := 0; i < 2; i++ {
+			if err := increment.HandleUpdate(context.Background(), update); err != nil {
+				t.Fatalf("HandleUpdate returned error %v", err)
+			}
+		}
+
+		s, err := m.Load(context.Background(), UserSessionKey(2))
+		if err != nil {
+			t.Fatalf("Load returned error %v", err)
+		}
+		var count int
+		if ok, _ := s.Get("count", &count); !ok || count != 2 {
+			t.Errorf("count is %d; want 2", count)
+		}
+
+		stale, _ := m.Load(context.Background(), ChatSessionKey(1))
+		fresh, _ := m.Load(context.Background(), ChatSessionKey(1))
+		fresh.Set("language", "de")
+		if err := m.Save(context.Background(), fresh); err != nil {
+			t.Fatalf("Save returned error %v", err)
+		}
+		stale.Set("language", "fr")
+		if err := m.Save(context.Background(), stale); !errors.Is(err, ErrSessionConflict) {
+			t.Errorf("Save err is %v; want %v", err, ErrSessionConflict)
+		}
+
+		// Clearing a stale session must not delete the newer one.
+		stale, _ = m.Load(context.Background(), ChatSessionKey(1))
+		fresh, _ = m.Load(context.Background(), ChatSessionKey(1))
+		fresh.Set("language", "it")
+		if err := m.Save(context.Background(), fresh); err != nil {
+			t.Fatalf("Save returned error %v", err)
+		}
+		stale.Clear()
+		if err := m.Save(context.Background(), stale); !errors.Is(err, ErrSessionConflict) {
+			t.Errorf("Save of cleared session err is %v; want %v", err, ErrSessionConflict)
+		}
+		s, _ = m.Load(context.Background(), ChatSessionKey(1))
+		var language string
+		if ok, _ := s.Get("language", &language); !ok || language != "it" {
+			t.Errorf("language is %q; want %q", language, "it")
+		}
+
+		fresh.Clear()
+		if err := m.Save(context.Background(), fresh); err != nil {
+			t.Errorf("Save of cleared session returned error %v", err)
+		}
+		if value, _, _ := store.Load(context.Background(), ChatSessionKey(1)); value != nil {
+			t.Errorf("cleared session is still stored: %s", value)
+		}
+	}
+}
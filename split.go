@@ -0,0 +1,304 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf16"
+)
+
+const (
+	MaxMessageTextLength = 4096
+	MaxCaptionLength     = 1024
+)
+
+// TextChunk is a part of a longer text together with the entities that apply
+// to it, with offsets relative to the start of the chunk.
+type TextChunk struct {
+	Text     string
+	Entities []MessageEntity
+}
+
+var splitSeparators = [][]uint16{
+	utf16.Encode([]rune("\n\n")),
+	utf16.Encode([]rune("\n")),
+	utf16.Encode([]rune(" ")),
+}
+
+// SplitText splits text into chunks of at most limit UTF-16 code units,
+// preferring paragraph, then line, then word boundaries. A chunk never ends
+// inside an entity unless the entity alone is longer than limit, in which case
+// the entity is continued in the next chunk. A limit below 1 is treated as 1,
+// and a chunk holds at least one character even if it is longer than limit.
+func SplitText(text string, entities []MessageEntity, limit int) []TextChunk {
+	if limit < 1 {
+		limit = 1
+	}
+
+	units := utf16.Encode([]rune(text))
+	var chunks []TextChunk
+	for pos := 0; pos < len(units); {
+		var chunk TextChunk
+		chunk, pos = splitNext(units, entities, pos, limit)
+		chunks = append(chunks, chunk)
+	}
+	if chunks == nil {
+		chunks = []TextChunk{{Text: text, Entities: entities}}
+	}
+	return chunks
+}
+
+// splitNext returns the chunk starting at pos and the position of the chunk
+// following it.
+func splitNext(units []uint16, entities []MessageEntity, pos, limit int) (TextChunk, int) {
+	if len(units)-pos <= limit {
+		return newTextChunk(units, entities, pos, len(units)), len(units)
+	}
+
+	end := pos + limit
+	for _, sep := range splitSeparators {
+		for i := end - len(sep); i > pos; i-- {
+			if equalUnits(units[i:i+len(sep)], sep) && !crossesEntity(entities, i, i+len(sep)) {
+				return newTextChunk(units, entities, pos, i), i + len(sep)
+			}
+		}
+	}
+
+	for i := end; i > pos; i-- {
+		if !isLowSurrogate(units[i]) && !crossesEntity(entities, i, i) {
+			return newTextChunk(units, entities, pos, i), i
+		}
+	}
+
+	if isLowSurrogate(units[end]) {
+		if end-1 > pos {
+			end--
+		} else {
+			end++
+		}
+	}
+	return newTextChunk(units, entities, pos, end), end
+}
+
+func newTextChunk(units []uint16, entities []MessageEntity, start, end int) TextChunk {
+	chunk := TextChunk{Text: string(utf16.Decode(units[start:end]))}
+	for _, e := range entities {
+		from, to := e.Offset, e.Offset+e.Length
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		if from >= to {
+			continue
+		}
+		e.Offset, e.Length = from-start, to-from
+		chunk.Entities = append(chunk.Entities, e)
+	}
+	return chunk
+}
+
+// crossesEntity reports whether cutting out units[from:to] would split an entity.
+func crossesEntity(entities []MessageEntity, from, to int) bool {
+	for _, e := range entities {
+		if e.Offset < to && e.Offset+e.Length > from {
+			if e.Offset >= from && e.Offset+e.Length <= to && from != to {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func equalUnits(a, b []uint16) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isLowSurrogate(u uint16) bool {
+	return u >= 0xdc00 && u <= 0xdfff
+}
+
+func textLength(s *string) int {
+	if s == nil {
+		return 0
+	}
+	return len(utf16.Encode([]rune(*s)))
+}
+
+// SendLongMessage sends a text of any length as a sequence of messages, each
+// replying to the previous one. Texts using ParseMode cannot be split, use
+// Entities instead. The reply markup is attached to the last message only.
+func (c *BotClient) SendLongMessage(ctx context.Context, options SendMessageOptions) ([]Message, error) {
+	if textLength(options.Text) <= MaxMessageTextLength {
+		message, err := c.SendMessage(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		return []Message{*message}, nil
+	}
+
+	if options.ParseMode != nil {
+		return nil, fmt.Errorf("cannot split text with parse mode %s, use entities instead", *options.ParseMode)
+	}
+
+	chunks := SplitText(*options.Text, options.Entities, MaxMessageTextLength)
+	messages := make([]Message, 0, len(chunks))
+	for i, chunk := range chunks {
+		opts := options
+		opts.Text = String(chunk.Text)
+		opts.Entities = chunk.Entities
+		if i > 0 {
+			opts.ReplyToMessageId = Int(messages[i-1].MessageId)
+			opts.ReplyParameters = nil
+		}
+		if i < len(chunks)-1 {
+			opts.ReplyMarkup = nil
+		}
+
+		message, err := c.SendMessage(ctx, opts)
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, *message)
+	}
+	return messages, nil
+}
+
+// splitCaption returns the part of caption that fits into a media message and
+// the overflow to be sent as follow-up text messages.
+func splitCaption(caption *string, entities []MessageEntity, parseMode *string) (*TextChunk, []TextChunk, error) {
+	if textLength(caption) <= MaxCaptionLength {
+		return nil, nil, nil
+	}
+
+	if parseMode != nil {
+		return nil, nil, fmt.Errorf("cannot split caption with parse mode %s, use caption entities instead", *parseMode)
+	}
+
+	units := utf16.Encode([]rune(*caption))
+	head, pos := splitNext(units, entities, 0, MaxCaptionLength)
+	var rest []TextChunk
+	for pos < len(units) {
+		var chunk TextChunk
+		chunk, pos = splitNext(units, entities, pos, MaxMessageTextLength)
+		rest = append(rest, chunk)
+	}
+	return &head, rest, nil
+}
+
+// sendCaptionOverflow sends the overflow of a caption as text messages, each
+// replying to the previous one, starting with the media message itself. The
+// thread and notification settings are taken from options.
+func (c *BotClient) sendCaptionOverflow(ctx context.Context, media *Message, rest []TextChunk, options SendMessageOptions) ([]Message, error) {
+	messages := []Message{*media}
+	for _, chunk := range rest {
+		message, err := c.SendMessage(ctx, SendMessageOptions{
+			ChatId:              Int(media.Chat.Id),
+			MessageThreadId:     options.MessageThreadId,
+			Text:                String(chunk.Text),
+			Entities:            chunk.Entities,
+			DisableNotification: options.DisableNotification,
+			ProtectContent:      options.ProtectContent,
+			ReplyToMessageId:    Int(messages[len(messages)-1].MessageId),
+		})
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, *message)
+	}
+	return messages, nil
+}
+
+// sendLongMedia sends a media message with send, moving the part of the
+// caption that does not fit into follow-up text messages.
+func (c *BotClient) sendLongMedia(ctx context.Context, caption *string, entities []MessageEntity, parseMode *string, overflow SendMessageOptions, send func(caption *string, entities []MessageEntity) (*Message, error)) ([]Message, error) {
+	head, rest, err := splitCaption(caption, entities, parseMode)
+	if err != nil {
+		return nil, err
+	}
+	if head != nil {
+		caption, entities = String(head.Text), head.Entities
+	}
+
+	message, err := send(caption, entities)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendCaptionOverflow(ctx, message, rest, overflow)
+}
+
+// SendLongPhoto sends a photo and moves the part of the caption that does not
+// fit into follow-up text messages.
+func (c *BotClient) SendLongPhoto(ctx context.Context, options SendPhotoOptions, photo *InputFile) ([]Message, error) {
+	overflow := SendMessageOptions{
+		MessageThreadId:     options.MessageThreadId,
+		DisableNotification: options.DisableNotification,
+		ProtectContent:      options.ProtectContent,
+	}
+	return c.sendLongMedia(ctx, options.Caption, options.CaptionEntities, options.ParseMode, overflow, func(caption *string, entities []MessageEntity) (*Message, error) {
+		options.Caption, options.CaptionEntities = caption, entities
+		return c.SendPhoto(ctx, options, photo)
+	})
+}
+
+// SendLongAudio sends an audio and moves the part of the caption that does not
+// fit into follow-up text messages.
+func (c *BotClient) SendLongAudio(ctx context.Context, options SendAudioOptions, audio, thumb *InputFile) ([]Message, error) {
+	overflow := SendMessageOptions{
+		MessageThreadId:     options.MessageThreadId,
+		DisableNotification: options.DisableNotification,
+		ProtectContent:      options.ProtectContent,
+	}
+	return c.sendLongMedia(ctx, options.Caption, options.CaptionEntities, options.ParseMode, overflow, func(caption *string, entities []MessageEntity) (*Message, error) {
+		options.Caption, options.CaptionEntities = caption, entities
+		return c.SendAudio(ctx, options, audio, thumb)
+	})
+}
+
+// SendLongDocument sends a document and moves the part of the caption that
+// does not fit into follow-up text messages.
+func (c *BotClient) SendLongDocument(ctx context.Context, options SendDocumentOptions, document, thumb *InputFile) ([]Message, error) {
+	overflow := SendMessageOptions{
+		MessageThreadId:     options.MessageThreadId,
+		DisableNotification: options.DisableNotification,
+		ProtectContent:      options.ProtectContent,
+	}
+	return c.sendLongMedia(ctx, options.Caption, options.CaptionEntities, options.ParseMode, overflow, func(caption *string, entities []MessageEntity) (*Message, error) {
+		options.Caption, options.CaptionEntities = caption, entities
+		return c.SendDocument(ctx, options, document, thumb)
+	})
+}
+
+// SendLongVideo sends a video and moves the part of the caption that does not
+// fit into follow-up text messages.
+func (c *BotClient) SendLongVideo(ctx context.Context, options SendVideoOptions, video, thumb *InputFile) ([]Message, error) {
+	overflow := SendMessageOptions{
+		MessageThreadId:     options.MessageThreadId,
+		DisableNotification: options.DisableNotification,
+		ProtectContent:      options.ProtectContent,
+	}
+	return c.sendLongMedia(ctx, options.Caption, options.CaptionEntities, options.ParseMode, overflow, func(caption *string, entities []MessageEntity) (*Message, error) {
+		options.Caption, options.CaptionEntities = caption, entities
+		return c.SendVideo(ctx, options, video, thumb)
+	})
+}
+
+// SendLongAnimation sends an animation and moves the part of the caption that
+// does not fit into follow-up text messages.
+func (c *BotClient) SendLongAnimation(ctx context.Context, options SendAnimationOptions, animation, thumb *InputFile) ([]Message, error) {
+	overflow := SendMessageOptions{
+		MessageThreadId:     options.MessageThreadId,
+		DisableNotification: options.DisableNotification,
+		ProtectContent:      options.ProtectContent,
+	}
+	return c.sendLongMedia(ctx, options.Caption, options.CaptionEntities, options.ParseMode, overflow, func(caption *string, entities []MessageEntity) (*Message, error) {
+		options.Caption, options.CaptionEntities = caption, entities
+		return c.SendAnimation(ctx, options, animation, thumb)
+	})
+}
@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []MessageEntity
+		limit    int
+		want     []TextChunk
+	}{
+		{
+			name:  "fits",
+			text:  "short",
+			limit: 10,
+			want:  []TextChunk{{Text: "short"}},
+		},
+		{
+			name:  "paragraph before line",
+			text:  "aaa\n\nbb\ncc",
+			limit: 9,
+			want:  []TextChunk{{Text: "aaa"}, {Text: "bb\ncc"}},
+		},
+		{
+			name:  "word boundary",
+			text:  "one two three",
+			limit: 8,
+			want:  []TextChunk{{Text: "one two"}, {Text: "three"}},
+		},
+		{
+			name:     "does not cut entity",
+			text:     "one two three",
+			entities: []MessageEntity{{Type: "bold", Offset: 4, Length: 9}},
+			limit:    10,
+			want: []TextChunk{
+				{Text: "one"},
+				{Text: "two three", Entities: []MessageEntity{{Type: "bold", Offset: 0, Length: 9}}},
+			},
+		},
+		{
+			name:     "entity longer than limit",
+			text:     "abcdef",
+			entities: []MessageEntity{{Type: "code", Offset: 0, Length: 6}},
+			limit:    4,
+			want: []TextChunk{
+				{Text: "abcd", Entities: []MessageEntity{{Type: "code", Offset: 0, Length: 4}}},
+				{Text: "ef", Entities: []MessageEntity{{Type: "code", Offset: 0, Length: 2}}},
+			},
+		},
+		{
+			name:  "surrogate pairs",
+			text:  "😀😀😀",
+			limit: 3,
+			want:  []TextChunk{{Text: "😀"}, {Text: "😀"}, {Text: "😀"}},
+		},
+		{
+			name:  "surrogate pair longer than limit",
+			text:  "a😀",
+			limit: 1,
+			want:  []TextChunk{{Text: "a"}, {Text: "😀"}},
+		},
+		{
+			name:  "limit below 1",
+			text:  "abc",
+			limit: 0,
+			want:  []TextChunk{{Text: "a"}, {Text: "b"}, {Text: "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := SplitText(tt.text, tt.entities, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: SplitText = %+v; want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBotClient_SendLongMessage(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	paragraph := strings.Repeat("a", 3000)
+	text := paragraph + "\n\n" + paragraph
+
+	var requests []SendMessageOptions
+	mux.HandleFunc("/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		v := new(SendMessageOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+		requests = append(requests, *v)
+
+		fmt.Fprintf(w, `{"ok": true, "result": {"message_id": %d}}`, len(requests))
+	})
+
+	messages, err := b.SendLongMessage(context.Background(), SendMessageOptions{
+		ChatId:           Int(1),
+		Text:             String(text),
+		ReplyToMessageId: Int(100),
+		ReplyParameters:  &ReplyParameters{MessageId: Int(100), Quote: String("question")},
+	})
+	if err != nil {
+		t.Fatalf("SendLongMessage returned error %v", err)
+	}
+
+	if got, want := len(messages), 2; got != want {
+		t.Fatalf("SendLongMessage sent %d messages; want %d", got, want)
+	}
+	if got, want := *requests[0].ReplyToMessageId, 100; got != want {
+		t.Errorf("first chunk replies to %d; want %d", got, want)
+	}
+	if got, want := *requests[1].ReplyToMessageId, messages[0].MessageId; got != want {
+		t.Errorf("second chunk replies to %d; want %d", got, want)
+	}
+	if requests[0].ReplyParameters == nil || requests[1].ReplyParameters != nil {
+		t.Errorf("reply parameters are %+v and %+v; want them on the first chunk only", requests[0].ReplyParameters, requests[1].ReplyParameters)
+	}
+	if got := *requests[1].Text; got != paragraph {
+		t.Errorf("second chunk has %d characters; want %d", len(got), len(paragraph))
+	}
+}
@@ -9,8 +9,13 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 const (
@@ -54,6 +59,11 @@ const (
 	apiSetChatAdministratorCustomTitle = "/setChatAdministratorCustomTitle"
 	apiSetChatPermissions              = "/setChatPermissions"
 	apiExportChatInviteLink            = "/exportChatInviteLink"
+	apiCreateChatInviteLink            = "/createChatInviteLink"
+	apiEditChatInviteLink              = "/editChatInviteLink"
+	apiRevokeChatInviteLink            = "/revokeChatInviteLink"
+	apiApproveChatJoinRequest          = "/approveChatJoinRequest"
+	apiDeclineChatJoinRequest          = "/declineChatJoinRequest"
 	apiSetChatPhoto                    = "/setChatPhoto"
 	apiDeleteChatPhoto                 = "/deleteChatPhoto"
 	apiSetChatTitle                    = "/setChatTitle"
@@ -69,20 +79,44 @@ const (
 	apiSetChatStickerSet               = "/setChatStickerSet"
 	apiDeleteChatStickerSet            = "/deleteChatStickerSet"
 	apiAnswerCallbackQuery             = "/answerCallbackQuery"
+	apiAnswerWebAppQuery               = "/answerWebAppQuery"
 	apiSetMyCommands                   = "/setMyCommands"
 	apiGetMyCommands                   = "/getMyCommands"
+	apiDeleteMyCommands                = "/deleteMyCommands"
+	apiSetMyName                       = "/setMyName"
+	apiGetMyName                       = "/getMyName"
+	apiSetMyDescription                = "/setMyDescription"
+	apiGetMyDescription                = "/getMyDescription"
+	apiSetMyShortDescription           = "/setMyShortDescription"
+	apiGetMyShortDescription           = "/getMyShortDescription"
+	apiSetChatMenuButton               = "/setChatMenuButton"
+	apiGetChatMenuButton               = "/getChatMenuButton"
+	apiSetMyDefaultAdministratorRights = "/setMyDefaultAdministratorRights"
+	apiGetMyDefaultAdministratorRights = "/getMyDefaultAdministratorRights"
 	apiEditMessageText                 = "/editMessageText"
 	apiEditMessageCaption              = "/editMessageCaption"
 	apiEditMessageMedia                = "/editMessageMedia"
 	apiEditMessageReplyMarkup          = "/editMessageReplyMarkup"
 	apiStopPoll                        = "/stopPoll"
 	apiDeleteMessage                   = "/deleteMessage"
+	apiDeleteMessages                  = "/deleteMessages"
+	apiForwardMessages                 = "/forwardMessages"
+	apiCopyMessages                    = "/copyMessages"
+	apiSetMessageReaction              = "/setMessageReaction"
+	apiGetForumTopicIconStickers       = "/getForumTopicIconStickers"
+	apiCreateForumTopic                = "/createForumTopic"
+	apiEditForumTopic                  = "/editForumTopic"
+	apiCloseForumTopic                 = "/closeForumTopic"
+	apiReopenForumTopic                = "/reopenForumTopic"
+	apiDeleteForumTopic                = "/deleteForumTopic"
+	apiUnpinAllForumTopicMessages      = "/unpinAllForumTopicMessages"
 )
 
 type BotClient struct {
-	token      string
-	httpClient HttpClient
-	BaseURL    string
+	token        string
+	httpClient   HttpClient
+	interceptors []Interceptor
+	BaseURL      string
 }
 
 type HttpClient interface {
@@ -110,6 +144,69 @@ func NewBotClient(botToken string, httpClient HttpClient, baseURL string) (*BotC
 	return t, nil
 }
 
+// BotId returns the id of the bot, the part of the token before the colon, or
+// 0 if the token is malformed.
+func (c *BotClient) BotId() int {
+	id, err := strconv.Atoi(strings.SplitN(c.token, ":", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// Redact replaces the token of the client in s, keeping the bot id only. Use
+// it on anything logged that may contain a request URL.
+func (c *BotClient) Redact(s string) string {
+	if c.token == "" {
+		return s
+	}
+
+	redacted := "<redacted>"
+	if id := c.BotId(); id != 0 {
+		redacted = strconv.Itoa(id) + ":<redacted>"
+	}
+	s = strings.ReplaceAll(s, c.token, redacted)
+	if escaped := url.PathEscape(c.token); escaped != c.token {
+		s = strings.ReplaceAll(s, escaped, redacted)
+	}
+	return s
+}
+
+// redactError removes the token from the errors returned by requests, which
+// usually contain the request URL.
+func (c *BotClient) redactError(err error) error {
+	var apiErr *ApiError
+	var urlErr *url.Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &apiErr):
+		apiErr.RequestURL = c.Redact(apiErr.RequestURL)
+		return err
+	case errors.As(err, &urlErr):
+		return &url.Error{Op: urlErr.Op, URL: c.Redact(urlErr.URL), Err: c.redactError(urlErr.Err)}
+	}
+
+	if msg := c.Redact(err.Error()); msg != err.Error() {
+		return &redactedError{err: err, msg: msg}
+	}
+	return err
+}
+
+// redactedError hides the message of an error containing the token.
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
 func (c *BotClient) GetMe(ctx context.Context) (*Bot, error) {
 	var bot Bot
 	err := c.getMethod(ctx, apiGetMe, &bot)
@@ -129,12 +226,41 @@ type BotCommand struct {
 	Description string `json:"description"`
 }
 
+const (
+	MaxBotCommandLength            = 32
+	MaxBotCommandDescriptionLength = 256
+)
+
+var botCommandPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// SetMyCommands sets the commands of the bot for a scope and language. The
+// commands are validated before the call.
 func (c *BotClient) SetMyCommands(ctx context.Context, options SetMyCommandsOptions) error {
+	for i, command := range options.Commands {
+		if err := validateBotCommand(command); err != nil {
+			return fmt.Errorf("bot command %d: %w", i, err)
+		}
+	}
 	return c.postJson(ctx, apiSetMyCommands, options, nil)
 }
 
 type SetMyCommandsOptions struct {
-	Commands []BotCommand `json:"commands"`
+	Commands     []BotCommand     `json:"commands"`
+	Scope        *BotCommandScope `json:"scope,omitempty"`
+	LanguageCode *string          `json:"language_code,omitempty"`
+}
+
+func validateBotCommand(command BotCommand) error {
+	if l := len(command.Command); l < 1 || l > MaxBotCommandLength {
+		return fmt.Errorf("command %q is %d characters, must be 1-%d", command.Command, l, MaxBotCommandLength)
+	}
+	if !botCommandPattern.MatchString(command.Command) {
+		return fmt.Errorf("command %q can only contain lowercase letters, digits and underscores", command.Command)
+	}
+	if l := utf8.RuneCountInString(command.Description); l < 1 || l > MaxBotCommandDescriptionLength {
+		return fmt.Errorf("description of command %q is %d characters, must be 1-%d", command.Command, l, MaxBotCommandDescriptionLength)
+	}
+	return nil
 }
 
 func (c *BotClient) GetMyCommands(ctx context.Context) ([]BotCommand, error) {
@@ -143,19 +269,140 @@ func (c *BotClient) GetMyCommands(ctx context.Context) ([]BotCommand, error) {
 	return commands, err
 }
 
+// GetMyCommandsWithOptions returns the commands of the bot for a scope and
+// language.
+func (c *BotClient) GetMyCommandsWithOptions(ctx context.Context, options GetMyCommandsOptions) ([]BotCommand, error) {
+	var commands []BotCommand
+	err := c.postJson(ctx, apiGetMyCommands, options, &commands)
+	return commands, err
+}
+
+type GetMyCommandsOptions struct {
+	Scope        *BotCommandScope `json:"scope,omitempty"`
+	LanguageCode *string          `json:"language_code,omitempty"`
+}
+
+func (c *BotClient) DeleteMyCommands(ctx context.Context, options DeleteMyCommandsOptions) error {
+	return c.postJson(ctx, apiDeleteMyCommands, options, nil)
+}
+
+type DeleteMyCommandsOptions struct {
+	Scope        *BotCommandScope `json:"scope,omitempty"`
+	LanguageCode *string          `json:"language_code,omitempty"`
+}
+
+const (
+	BotCommandScopeTypeDefault               = "default"
+	BotCommandScopeTypeAllPrivateChats       = "all_private_chats"
+	BotCommandScopeTypeAllGroupChats         = "all_group_chats"
+	BotCommandScopeTypeAllChatAdministrators = "all_chat_administrators"
+	BotCommandScopeTypeChat                  = "chat"
+	BotCommandScopeTypeChatAdministrators    = "chat_administrators"
+	BotCommandScopeTypeChatMember            = "chat_member"
+)
+
+// BotCommandScope is the scope of users for which commands are set, see the
+// BotCommandScope functions.
+type BotCommandScope struct {
+	Type   string `json:"type"`
+	ChatId *int   `json:"chat_id,omitempty"`
+	UserId *int   `json:"user_id,omitempty"`
+}
+
+func BotCommandScopeDefault() *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeDefault}
+}
+
+func BotCommandScopeAllPrivateChats() *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeAllPrivateChats}
+}
+
+func BotCommandScopeAllGroupChats() *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeAllGroupChats}
+}
+
+func BotCommandScopeAllChatAdministrators() *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeAllChatAdministrators}
+}
+
+func BotCommandScopeChat(chatId int) *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeChat, ChatId: Int(chatId)}
+}
+
+func BotCommandScopeChatAdministrators(chatId int) *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeChatAdministrators, ChatId: Int(chatId)}
+}
+
+func BotCommandScopeChatMember(chatId, userId int) *BotCommandScope {
+	return &BotCommandScope{Type: BotCommandScopeTypeChatMember, ChatId: Int(chatId), UserId: Int(userId)}
+}
+
+// ApiCall is a call to a Bot API method, as seen by interceptors.
+type ApiCall struct {
+	// Method is the name of the Bot API method, e.g. "sendMessage".
+	Method string
+	// Options is the options struct sent, or nil.
+	Options interface{}
+	// Result is the pointer the result is decoded into, or nil.
+	Result interface{}
+	// Duration is the time the request took, set once it returned.
+	Duration time.Duration
+}
+
+// Invoker performs a call. An error returned by the server is an *ApiError.
+type Invoker func(ctx context.Context, call *ApiCall) error
+
+// Interceptor is called around every call made by a BotClient. It may inspect
+// or modify the call, and must call invoke to perform it.
+type Interceptor func(ctx context.Context, call *ApiCall, invoke Invoker) error
+
+// Use adds interceptors to the calls of the client. The first interceptor
+// added is the outermost one. Use must not be called concurrently with calls.
+func (c *BotClient) Use(interceptors ...Interceptor) {
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+func (c *BotClient) call(ctx context.Context, api string, options, result interface{}, invoke Invoker) error {
+	call := &ApiCall{
+		Method:  strings.TrimPrefix(api, "/"),
+		Options: options,
+		Result:  result,
+	}
+
+	next := func(ctx context.Context, call *ApiCall) error {
+		start := time.Now()
+		err := invoke(ctx, call)
+		call.Duration = time.Since(start)
+		return err
+	}
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, invoke := c.interceptors[i], next
+		next = func(ctx context.Context, call *ApiCall) error {
+			return interceptor(ctx, call, invoke)
+		}
+	}
+	return next(ctx, call)
+}
+
 func (c *BotClient) getMethod(ctx context.Context, api string, out interface{}) error {
-	url := c.BaseURL + api
-	return getJson(ctx, c.httpClient, url, out)
+	return c.call(ctx, api, nil, out, func(ctx context.Context, call *ApiCall) error {
+		url := c.BaseURL + api
+		return c.redactError(getJson(ctx, c.httpClient, url, call.Result))
+	})
 }
 
 func (c *BotClient) postJson(ctx context.Context, api string, body, out interface{}) error {
-	url := c.BaseURL + api
-	return postJson(ctx, c.httpClient, url, body, out)
+	return c.call(ctx, api, body, out, func(ctx context.Context, call *ApiCall) error {
+		url := c.BaseURL + api
+		return c.redactError(postJson(ctx, c.httpClient, url, call.Options, call.Result))
+	})
 }
 
 func (c *BotClient) postMultipart(ctx context.Context, api string, in, out interface{}, multipartFiles ...*multiPartFile) error {
-	url := c.BaseURL + api
-	return postMultipart(ctx, c.httpClient, url, in, out, multipartFiles...)
+	return c.call(ctx, api, in, out, func(ctx context.Context, call *ApiCall) error {
+		url := c.BaseURL + api
+		return c.redactError(postMultipart(ctx, c.httpClient, url, call.Options, call.Result, multipartFiles...))
+	})
 }
 
 type multiPartFile struct {
@@ -307,13 +554,41 @@ type ApiResponse struct {
 
 type ApiError struct {
 	OriginalResponse *ApiResponse
-	RequestURL       string
+	// RequestURL is the URL of the request, with the token redacted.
+	RequestURL string
 }
 
 func (r *ApiError) Error() string {
 	return fmt.Sprintf("%s : %d %v", r.RequestURL, r.OriginalResponse.ErrorCode, r.OriginalResponse.Description)
 }
 
+// IsMessageNotModified reports whether err was returned for an edit leaving
+// the message unchanged.
+func IsMessageNotModified(err error) bool {
+	return isApiErrorDescription(err, "message is not modified")
+}
+
+// IsMessageExpired reports whether err was returned for an edit of a message
+// that was deleted or can no longer be edited.
+func IsMessageExpired(err error) bool {
+	return isApiErrorDescription(err, "message to edit not found") ||
+		isApiErrorDescription(err, "message can't be edited")
+}
+
+// IsQueryExpired reports whether err was returned for an answer to a query
+// that is too old.
+func IsQueryExpired(err error) bool {
+	return isApiErrorDescription(err, "query is too old")
+}
+
+func isApiErrorDescription(err error, description string) bool {
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.OriginalResponse == nil {
+		return false
+	}
+	return strings.Contains(apiErr.OriginalResponse.Description, description)
+}
+
 type ResponseParameters struct {
 	MigrateToChatId int `json:"migrate_to_chat_id"`
 	RetryAfter      int `json:"retry_after"`
@@ -3,10 +3,13 @@ package telegram
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -215,8 +218,10 @@ func TestBotClient_SetMyCommands(t *testing.T) {
 
 	opts := SetMyCommandsOptions{
 		Commands: []BotCommand{
-			{"/firstCommand", "Description of first bot command"},
-			{"/secondCommand", "Description of second bot command"}},
+			{"first_command", "Description of first bot command"},
+			{"second_command", "Description of second bot command"}},
+		Scope:        BotCommandScopeChatAdministrators(-100),
+		LanguageCode: String("en"),
 	}
 
 	mux.HandleFunc("/setMyCommands", func(w http.ResponseWriter, r *http.Request) {
@@ -240,3 +245,170 @@ func TestBotClient_SetMyCommands(t *testing.T) {
 		t.Errorf("close returned error  %v", err)
 	}
 }
+
+func TestBotClient_SetMyCommandsInvalid(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/setMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("setMyCommands called with invalid commands")
+	})
+
+	for _, command := range []BotCommand{
+		{"/start", "Start"},
+		{"Start", "Start"},
+		{"", "Empty"},
+		{"a_very_long_command_name_over_32_chars", "Too long"},
+		{"start", ""},
+	} {
+		opts := SetMyCommandsOptions{Commands: []BotCommand{command}}
+		if err := b.SetMyCommands(context.Background(), opts); err == nil {
+			t.Errorf("SetMyCommands(%+v) err is nil; want validation error", command)
+		}
+	}
+}
+
+func TestBotClient_GetMyCommands(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/getMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"ok": true, "result": [{"command": "start", "description": "Start"}]}`)
+	})
+
+	got, err := b.GetMyCommands(context.Background())
+	if err != nil {
+		t.Fatalf("GetMyCommands returned error %v", err)
+	}
+	if want := []BotCommand{{"start", "Start"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMyCommands returned %+v; want %+v", got, want)
+	}
+}
+
+func TestBotClient_GetMyCommandsWithOptions(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	opts := GetMyCommandsOptions{Scope: BotCommandScopeChatMember(-100, 8), LanguageCode: String("de")}
+
+	mux.HandleFunc("/getMyCommands", func(w http.ResponseWriter, r *http.Request) {
+		v := new(GetMyCommandsOptions)
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, v)
+
+		if !reflect.DeepEqual(*v, opts) {
+			t.Errorf("Request body = %+v, want %+v", *v, opts)
+		}
+
+		fmt.Fprint(w, `{"ok": true, "result": [{"command": "start", "description": "Starten"}]}`)
+	})
+
+	got, err := b.GetMyCommandsWithOptions(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("GetMyCommandsWithOptions returned error %v", err)
+	}
+	if want := []BotCommand{{"start", "Starten"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMyCommandsWithOptions returned %+v; want %+v", got, want)
+	}
+}
+
+func TestBotClient_Use(t *testing.T) {
+	b, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w,
+			`{
+    					"ok": false,
+    					"error_code": 429,
+    					"description": "Too Many Requests: retry after 5",
+    					"parameters": {"retry_after": 5}
+					}`,
+		)
+	})
+
+	var calls []string
+	var observed *ApiCall
+	b.Use(
+		func(ctx context.Context, call *ApiCall, invoke Invoker) error {
+			calls = append(calls, "outer")
+			err := invoke(ctx, call)
+			observed = call
+			return err
+		},
+		func(ctx context.Context, call *ApiCall, invoke Invoker) error {
+			calls = append(calls, "inner")
+			return invoke(ctx, call)
+		},
+	)
+
+	opts := SendMessageOptions{ChatId: Int(1), Text: String("hello")}
+	_, err := b.SendMessage(context.Background(), opts)
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.OriginalResponse.Parameters.RetryAfter != 5 {
+		t.Errorf("SendMessage err is %v; want ApiError retrying after 5", err)
+	}
+	if want := []string{"outer", "inner"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("interceptors called %v; want %v", calls, want)
+	}
+	if observed.Method != "sendMessage" || !reflect.DeepEqual(observed.Options, opts) || observed.Duration <= 0 {
+		t.Errorf("interceptor observed %+v; want sendMessage with options and duration", observed)
+	}
+}
+
+func TestBotClient_UseShortCircuit(t *testing.T) {
+	b, _, teardown := setup()
+	defer teardown()
+
+	b.Use(func(ctx context.Context, call *ApiCall, invoke Invoker) error {
+		bot := call.Result.(*Bot)
+		bot.Username = "cached_bot"
+		return nil
+	})
+
+	got, err := b.GetMe(context.Background())
+	if err != nil {
+		t.Errorf("getMe returned error %v", err)
+	}
+	if got.Username != "cached_bot" {
+		t.Errorf("getMe username is %v; want cached_bot", got.Username)
+	}
+}
+
+func TestBotClient_Redact(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	b, _ := NewBotClient("123456:SECRET", nil, "")
+	b.BaseURL = server.URL + "/bot123456:SECRET"
+
+	mux.HandleFunc("/bot123456:SECRET/sendMessage", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error_code": 400, "description": "Bad Request: chat not found"}`)
+	})
+
+	if got := b.BotId(); got != 123456 {
+		t.Errorf("BotId is %d; want 123456", got)
+	}
+
+	_, err := b.SendMessage(context.Background(), SendMessageOptions{ChatId: Int(1), Text: String("hello")})
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("SendMessage err is %v; want ApiError", err)
+	}
+	if want := server.URL + "/bot123456:<redacted>/sendMessage : 400 Bad Request: chat not found"; err.Error() != want {
+		t.Errorf("SendMessage err is %q; want %q", err.Error(), want)
+	}
+
+	server.Close()
+	_, err = b.GetMe(context.Background())
+	if err == nil || strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("GetMe err is %v; want transport error without token", err)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("GetMe err is %T; want *url.Error", err)
+	}
+}
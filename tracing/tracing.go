@@ -0,0 +1,123 @@
+// Package tracing instruments update handling and Bot API calls with
+// OpenTelemetry. Every update handled gets a span, and the calls made by a
+// telegram.BotClient with the context of the handler become its children.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	telegram "github.com/ccl17"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ccl17/tracing"
+
+const (
+	UpdateIdKey     = attribute.Key("telegram.update.id")
+	UpdateTypeKey   = attribute.Key("telegram.update.type")
+	ChatIdKey       = attribute.Key("telegram.chat.id")
+	UserIdKey       = attribute.Key("telegram.user.id")
+	MethodKey       = attribute.Key("telegram.api.method")
+	ApiErrorCodeKey = attribute.Key("telegram.api.error_code")
+	RetryAfterKey   = attribute.Key("telegram.api.retry_after")
+)
+
+const (
+	spanUpdatePrefix = "telegram.update "
+	spanApiPrefix    = "telegram.api "
+)
+
+// Tracer creates the spans. Add Middleware to the routers and Interceptor to
+// the clients to trace.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// New creates a Tracer using provider, or the global tracer provider if nil.
+func New(provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &Tracer{tracer: provider.Tracer(instrumentationName)}
+}
+
+// Middleware starts a span for every update handled, see telegram.Router.Use.
+func (t *Tracer) Middleware() telegram.Middleware {
+	return func(next telegram.Handler) telegram.Handler {
+		return telegram.HandlerFunc(func(ctx context.Context, update *telegram.Update) error {
+			attributes := []attribute.KeyValue{
+				UpdateIdKey.Int(update.UpdateId),
+				UpdateTypeKey.String(update.Type()),
+			}
+			if chat := update.EffectiveChat(); chat != nil {
+				attributes = append(attributes, ChatIdKey.Int(chat.Id))
+			}
+			if user := update.EffectiveUser(); user != nil {
+				attributes = append(attributes, UserIdKey.Int(user.Id))
+			}
+
+			ctx, span := t.tracer.Start(ctx, spanUpdatePrefix+update.Type(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attributes...),
+			)
+			defer span.End()
+
+			err := next.HandleUpdate(ctx, update)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		})
+	}
+}
+
+// Interceptor starts a span for every call of a client, see
+// telegram.BotClient.Use.
+func (t *Tracer) Interceptor() telegram.Interceptor {
+	return func(ctx context.Context, call *telegram.ApiCall, invoke telegram.Invoker) error {
+		attributes := []attribute.KeyValue{MethodKey.String(call.Method)}
+		if chatId, ok := optionsChatId(call.Options); ok {
+			attributes = append(attributes, ChatIdKey.Int(chatId))
+		}
+
+		ctx, span := t.tracer.Start(ctx, spanApiPrefix+call.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attributes...),
+		)
+		defer span.End()
+
+		err := invoke(ctx, call)
+		if err != nil {
+			var apiErr *telegram.ApiError
+			if errors.As(err, &apiErr) && apiErr.OriginalResponse != nil {
+				span.SetAttributes(ApiErrorCodeKey.Int(apiErr.OriginalResponse.ErrorCode))
+				if p := apiErr.OriginalResponse.Parameters; p != nil && p.RetryAfter > 0 {
+					span.SetAttributes(RetryAfterKey.Int(p.RetryAfter))
+				}
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
+
+// optionsChatId returns the ChatId field of an options struct, if set.
+func optionsChatId(options interface{}) (int, bool) {
+	v := reflect.Indirect(reflect.ValueOf(options))
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	field := v.FieldByName("ChatId")
+	if !field.IsValid() || field.Kind() != reflect.Ptr || field.IsNil() || field.Elem().Kind() != reflect.Int {
+		return 0, false
+	}
+	return int(field.Elem().Int()), true
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	telegram "github.com/ccl17"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error_code": 403, "description": "Forbidden: bot was blocked by the user"}`)
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := New(provider)
+
+	client, _ := telegram.NewBotClient("123:secret", nil, "")
+	client.BaseURL = server.URL
+	client.Use(tracer.Interceptor())
+
+	r := telegram.NewRouter()
+	r.Use(tracer.Middleware())
+	r.Message(telegram.HandlerFunc(func(ctx context.Context, update *telegram.Update) error {
+		_, err := client.SendMessage(ctx, telegram.SendMessageOptions{
+			ChatId: telegram.Int(update.Message.Chat.Id),
+			Text:   telegram.String("hello"),
+		})
+		return err
+	}))
+
+	update := &telegram.Update{UpdateId: 1, Message: &telegram.Message{Chat: &telegram.Chat{Id: 42}, From: &telegram.User{Id: 7}}}
+	if err := r.HandleUpdate(context.Background(), update); err == nil {
+		t.Fatalf("HandleUpdate err is nil; want ApiError")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans; want 2", len(spans))
+	}
+	apiSpan, updateSpan := spans[0], spans[1]
+
+	if got, want := updateSpan.Name, "telegram.update message"; got != want {
+		t.Errorf("update span name is %q; want %q", got, want)
+	}
+	if got, want := apiSpan.Name, "telegram.api sendMessage"; got != want {
+		t.Errorf("api span name is %q; want %q", got, want)
+	}
+	if apiSpan.Parent.SpanID() != updateSpan.SpanContext.SpanID() {
+		t.Errorf("api span is not a child of the update span")
+	}
+	if apiSpan.Status.Code != codes.Error {
+		t.Errorf("api span status is %v; want %v", apiSpan.Status.Code, codes.Error)
+	}
+
+	want := map[attribute.Key]attribute.Value{
+		MethodKey:       attribute.StringValue("sendMessage"),
+		ChatIdKey:       attribute.IntValue(42),
+		ApiErrorCodeKey: attribute.IntValue(403),
+	}
+	got := make(map[attribute.Key]attribute.Value)
+	for _, kv := range apiSpan.Attributes {
+		got[kv.Key] = kv.Value
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("api span attribute %s is %v; want %v", k, got[k].Emit(), v.Emit())
+		}
+	}
+}
@@ -5,20 +5,169 @@ import (
 )
 
 type Update struct {
-	UpdateId           int                 `json:"update_id"`
-	Message            *Message            `json:"message"`
-	EditedMessage      *Message            `json:"edited_message"`
-	ChannelPost        *Message            `json:"channel_post"`
-	EditedChannelPost  *Message            `json:"edited_channel_post"`
-	InlineQuery        *InlineQuery        `json:"inline_query"`
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result"`
-	CallbackQuery      *CallbackQuery      `json:"callback_query"`
-	Poll               *Poll               `json:"poll"`
-	PollAnswer         *PollAnswer         `json:"poll_answer"`
+	UpdateId             int                          `json:"update_id"`
+	Message              *Message                     `json:"message"`
+	EditedMessage        *Message                     `json:"edited_message"`
+	ChannelPost          *Message                     `json:"channel_post"`
+	EditedChannelPost    *Message                     `json:"edited_channel_post"`
+	InlineQuery          *InlineQuery                 `json:"inline_query"`
+	ChosenInlineResult   *ChosenInlineResult          `json:"chosen_inline_result"`
+	CallbackQuery        *CallbackQuery               `json:"callback_query"`
+	Poll                 *Poll                        `json:"poll"`
+	PollAnswer           *PollAnswer                  `json:"poll_answer"`
+	MyChatMember         *ChatMemberUpdated           `json:"my_chat_member"`
+	ChatMember           *ChatMemberUpdated           `json:"chat_member"`
+	ChatJoinRequest      *ChatJoinRequest             `json:"chat_join_request"`
+	MessageReaction      *MessageReactionUpdated      `json:"message_reaction"`
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count"`
 	//ShippingQuery      *ShippingQuery      `json:"shipping_query"`
 	// PreCheckoutQuery   *PreCheckoutQuery   `json:"pre_checkout_query"`
 }
 
+const (
+	UpdateTypeMessage              = "message"
+	UpdateTypeEditedMessage        = "edited_message"
+	UpdateTypeChannelPost          = "channel_post"
+	UpdateTypeEditedChannelPost    = "edited_channel_post"
+	UpdateTypeInlineQuery          = "inline_query"
+	UpdateTypeChosenInlineResult   = "chosen_inline_result"
+	UpdateTypeCallbackQuery        = "callback_query"
+	UpdateTypePoll                 = "poll"
+	UpdateTypePollAnswer           = "poll_answer"
+	UpdateTypeMyChatMember         = "my_chat_member"
+	UpdateTypeChatMember           = "chat_member"
+	UpdateTypeChatJoinRequest      = "chat_join_request"
+	UpdateTypeMessageReaction      = "message_reaction"
+	UpdateTypeMessageReactionCount = "message_reaction_count"
+)
+
+// AllUpdateTypes returns every update type, for GetUpdatesOptions.AllowedUpdates
+// and SetWebhookOptions.AllowedUpdates. Chat member updates are only sent when
+// requested explicitly, reaction updates only if the bot is an administrator.
+func AllUpdateTypes() []string {
+	return []string{
+		UpdateTypeMessage,
+		UpdateTypeEditedMessage,
+		UpdateTypeChannelPost,
+		UpdateTypeEditedChannelPost,
+		UpdateTypeInlineQuery,
+		UpdateTypeChosenInlineResult,
+		UpdateTypeCallbackQuery,
+		UpdateTypePoll,
+		UpdateTypePollAnswer,
+		UpdateTypeMyChatMember,
+		UpdateTypeChatMember,
+		UpdateTypeChatJoinRequest,
+		UpdateTypeMessageReaction,
+		UpdateTypeMessageReactionCount,
+	}
+}
+
+// Type returns the name of the field set in the update, as used in
+// GetUpdatesOptions.AllowedUpdates, or an empty string if it is unknown.
+func (u *Update) Type() string {
+	switch {
+	case u.Message != nil:
+		return UpdateTypeMessage
+	case u.EditedMessage != nil:
+		return UpdateTypeEditedMessage
+	case u.ChannelPost != nil:
+		return UpdateTypeChannelPost
+	case u.EditedChannelPost != nil:
+		return UpdateTypeEditedChannelPost
+	case u.InlineQuery != nil:
+		return UpdateTypeInlineQuery
+	case u.ChosenInlineResult != nil:
+		return UpdateTypeChosenInlineResult
+	case u.CallbackQuery != nil:
+		return UpdateTypeCallbackQuery
+	case u.Poll != nil:
+		return UpdateTypePoll
+	case u.PollAnswer != nil:
+		return UpdateTypePollAnswer
+	case u.MyChatMember != nil:
+		return UpdateTypeMyChatMember
+	case u.ChatMember != nil:
+		return UpdateTypeChatMember
+	case u.ChatJoinRequest != nil:
+		return UpdateTypeChatJoinRequest
+	case u.MessageReaction != nil:
+		return UpdateTypeMessageReaction
+	case u.MessageReactionCount != nil:
+		return UpdateTypeMessageReactionCount
+	}
+	return ""
+}
+
+// EffectiveMessage returns the message of the update, whether new, edited or
+// attached to a callback query, or nil.
+func (u *Update) EffectiveMessage() *Message {
+	switch {
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.Message
+	}
+	return nil
+}
+
+// EffectiveChat returns the chat the update belongs to, or nil.
+func (u *Update) EffectiveChat() *Chat {
+	switch {
+	case u.MyChatMember != nil:
+		return u.MyChatMember.Chat
+	case u.ChatMember != nil:
+		return u.ChatMember.Chat
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.Chat
+	case u.MessageReaction != nil:
+		return u.MessageReaction.Chat
+	case u.MessageReactionCount != nil:
+		return u.MessageReactionCount.Chat
+	}
+	if m := u.EffectiveMessage(); m != nil {
+		return m.Chat
+	}
+	return nil
+}
+
+// EffectiveUser returns the user who triggered the update, or nil.
+func (u *Update) EffectiveUser() *User {
+	switch {
+	case u.Message != nil:
+		return u.Message.From
+	case u.EditedMessage != nil:
+		return u.EditedMessage.From
+	case u.ChannelPost != nil:
+		return u.ChannelPost.From
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost.From
+	case u.InlineQuery != nil:
+		return u.InlineQuery.From
+	case u.ChosenInlineResult != nil:
+		return u.ChosenInlineResult.From
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.From
+	case u.PollAnswer != nil:
+		return u.PollAnswer.User
+	case u.MyChatMember != nil:
+		return u.MyChatMember.From
+	case u.ChatMember != nil:
+		return u.ChatMember.From
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.From
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	}
+	return nil
+}
+
 type WebhookInfo struct {
 	Url                  string   `json:"url"`
 	HasCustomCertificate bool     `json:"has_custom_certificate"`
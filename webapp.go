@@ -0,0 +1,144 @@
+package telegram
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// authDateSkew is the clock difference tolerated for auth dates in the future.
+const authDateSkew = time.Minute
+
+var (
+	ErrWebAppInitDataInvalid = errors.New("web app init data is invalid or has been tampered with")
+	ErrWebAppInitDataExpired = errors.New("web app init data has expired")
+)
+
+type WebAppInfo struct {
+	Url string `json:"url"`
+}
+
+// WebAppData is the data sent by a Web App opened from a keyboard button.
+type WebAppData struct {
+	Data       string `json:"data"`
+	ButtonText string `json:"button_text"`
+}
+
+type SentWebAppMessage struct {
+	InlineMessageId string `json:"inline_message_id"`
+}
+
+// AnswerWebAppQuery sends a message on behalf of the user who opened the Web
+// App through an inline button, with the query id of WebAppInitData.
+func (c *BotClient) AnswerWebAppQuery(ctx context.Context, options AnswerWebAppQueryOptions) (*SentWebAppMessage, error) {
+	var sentWebAppMessage SentWebAppMessage
+	err := c.postJson(ctx, apiAnswerWebAppQuery, options, &sentWebAppMessage)
+	return &sentWebAppMessage, err
+}
+
+type AnswerWebAppQueryOptions struct {
+	WebAppQueryId *string `json:"web_app_query_id,omitempty"`
+	// Result is an InlineQueryResult, marshalled as is.
+	Result interface{} `json:"result,omitempty"`
+}
+
+// WebAppInitData is the data passed to a Web App when it is opened, see
+// ValidateWebAppInitData.
+type WebAppInitData struct {
+	QueryId      string
+	User         *User
+	Receiver     *User
+	Chat         *Chat
+	ChatType     string
+	ChatInstance string
+	StartParam   string
+	CanSendAfter int
+	AuthDate     time.Time
+}
+
+// ValidateWebAppInitData checks the signature of initData, the raw query
+// string received by a Web App, and parses it. Data older than maxAge is
+// rejected, unless maxAge is 0. Data dated in the future is always rejected.
+func ValidateWebAppInitData(token, initData string, maxAge time.Duration) (*WebAppInitData, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, ErrWebAppInitDataInvalid
+	}
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(token))
+	if !checkDataHash(values, secret) {
+		return nil, ErrWebAppInitDataInvalid
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, ErrWebAppInitDataInvalid
+	}
+	data := &WebAppInitData{
+		QueryId:      values.Get("query_id"),
+		ChatType:     values.Get("chat_type"),
+		ChatInstance: values.Get("chat_instance"),
+		StartParam:   values.Get("start_param"),
+		AuthDate:     time.Unix(authDate, 0),
+	}
+	if time.Until(data.AuthDate) > authDateSkew {
+		return nil, ErrWebAppInitDataInvalid
+	}
+	if maxAge > 0 && time.Since(data.AuthDate) > maxAge {
+		return nil, ErrWebAppInitDataExpired
+	}
+
+	if v := values.Get("can_send_after"); v != "" {
+		if data.CanSendAfter, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("decode web app init data can_send_after: %w", err)
+		}
+	}
+	fields := map[string]interface{}{"user": &data.User, "receiver": &data.Receiver, "chat": &data.Chat}
+	for name, field := range fields {
+		if v := values.Get(name); v != "" {
+			if err := json.Unmarshal([]byte(v), field); err != nil {
+				return nil, fmt.Errorf("decode web app init data %s: %w", name, err)
+			}
+		}
+	}
+	return data, nil
+}
+
+// ValidateWebAppInitData validates initData with the token of the client, see
+// ValidateWebAppInitData.
+func (c *BotClient) ValidateWebAppInitData(initData string, maxAge time.Duration) (*WebAppInitData, error) {
+	return ValidateWebAppInitData(c.token, initData, maxAge)
+}
+
+// checkDataHash reports whether the hash field of values is the HMAC of the
+// other fields, sorted and joined by newlines.
+func checkDataHash(values url.Values, secret []byte) bool {
+	hash, err := hex.DecodeString(values.Get("hash"))
+	if err != nil || len(hash) == 0 {
+		return false
+	}
+
+	pairs := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			pairs = append(pairs, key+"="+values.Get(key))
+		}
+	}
+	sort.Strings(pairs)
+	return hmac.Equal(hash, hmacSHA256(secret, []byte(strings.Join(pairs, "\n"))))
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
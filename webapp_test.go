@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// webAppTestToken and the hashes below are a test vector computed with
+// openssl, independently of ValidateWebAppInitData.
+const webAppTestToken = "123456:TEST-token"
+
+func webAppTestInitData(authDate, hash string) string {
+	values := url.Values{
+		"query_id":      {"AAH"},
+		"user":          {`{"id":7,"first_name":"Ann","username":"ann","language_code":"en"}`},
+		"chat_instance": {"-123"},
+		"start_param":   {"promo"},
+		"auth_date":     {authDate},
+	}
+	if hash != "" {
+		values.Set("hash", hash)
+	}
+	return values.Encode()
+}
+
+func TestValidateWebAppInitData(t *testing.T) {
+	initData := webAppTestInitData("1700000000", "1f6c7a3d037aa7b69afc1a6e847bf32501aa225c0957d45c2071116ea9a31fde")
+
+	data, err := ValidateWebAppInitData(webAppTestToken, initData, 0)
+	if err != nil {
+		t.Fatalf("ValidateWebAppInitData returned error %v", err)
+	}
+	if data.User == nil || data.User.Id != 7 || data.User.Username != "ann" {
+		t.Errorf("User is %+v; want user 7", data.User)
+	}
+	if data.QueryId != "AAH" || data.ChatInstance != "-123" || data.StartParam != "promo" || data.AuthDate.Unix() != 1700000000 {
+		t.Errorf("ValidateWebAppInitData returned %+v", data)
+	}
+
+	if _, err := ValidateWebAppInitData(webAppTestToken, initData, time.Hour); err != ErrWebAppInitDataExpired {
+		t.Errorf("ValidateWebAppInitData of old data err is %v; want %v", err, ErrWebAppInitDataExpired)
+	}
+	if _, err := ValidateWebAppInitData("other-token", initData, 0); err != ErrWebAppInitDataInvalid {
+		t.Errorf("ValidateWebAppInitData with other token err is %v; want %v", err, ErrWebAppInitDataInvalid)
+	}
+
+	tampered := strings.Replace(initData, "promo", "other", 1)
+	if _, err := ValidateWebAppInitData(webAppTestToken, tampered, 0); err != ErrWebAppInitDataInvalid {
+		t.Errorf("ValidateWebAppInitData of tampered data err is %v; want %v", err, ErrWebAppInitDataInvalid)
+	}
+}
+
+func TestValidateWebAppInitData_FutureAuthDate(t *testing.T) {
+	initData := webAppTestInitData("4102444800", "2e3b07cc1697217b56743c150978644d2833eb88f5a0b517ed6770cc8c73cc8a")
+	if _, err := ValidateWebAppInitData(webAppTestToken, initData, time.Hour); err != ErrWebAppInitDataInvalid {
+		t.Errorf("ValidateWebAppInitData dated in the future err is %v; want %v", err, ErrWebAppInitDataInvalid)
+	}
+}
+
+func TestValidateWebAppInitData_MissingHash(t *testing.T) {
+	initData := webAppTestInitData("1700000000", "")
+	if _, err := ValidateWebAppInitData(webAppTestToken, initData, 0); err != ErrWebAppInitDataInvalid {
+		t.Errorf("ValidateWebAppInitData without hash err is %v; want %v", err, ErrWebAppInitDataInvalid)
+	}
+}
@@ -0,0 +1,37 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WebhookHandler handles the updates delivered to a webhook. Updates are
+// handled before responding, so that Telegram delivers an update again if
+// handling fails. Wrap the handler with UpdateTracker.Wrap to skip updates
+// delivered more than once.
+type WebhookHandler struct {
+	handler Handler
+}
+
+func NewWebhookHandler(handler Handler) *WebhookHandler {
+	return &WebhookHandler{handler: handler}
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var update Update
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.handler.HandleUpdate(r.Context(), &update); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,147 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+var ErrWorkerPoolClosed = errors.New("worker pool is shut down")
+
+type WorkerPoolOptions struct {
+	// Workers is the number of updates handled concurrently, runtime.NumCPU()
+	// if zero.
+	Workers int
+	// QueueSize is the number of updates waiting for each worker, 100 if zero.
+	QueueSize int
+	// OnError is called with the errors returned by the handler.
+	OnError func(ctx context.Context, update *Update, err error)
+}
+
+// WorkerPool handles updates concurrently while handling the updates of a chat
+// in the order they were submitted. Inline queries, chosen inline results,
+// callback queries and poll answers are ordered per user instead.
+type WorkerPool struct {
+	handler Handler
+	onError func(ctx context.Context, update *Update, err error)
+	queues  []chan Update
+	wg      sync.WaitGroup
+
+	// mu guards closed and the registration of senders, which are waited
+	// for before the queues are closed.
+	mu        sync.RWMutex
+	closed    bool
+	senders   sync.WaitGroup
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewWorkerPool(handler Handler, options WorkerPoolOptions) *WorkerPool {
+	workers := options.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	queueSize := options.QueueSize
+	if queueSize < 1 {
+		queueSize = 100
+	}
+
+	p := &WorkerPool{
+		handler: handler,
+		onError: options.OnError,
+		queues:  make([]chan Update, workers),
+		done:    make(chan struct{}),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan Update, queueSize)
+	}
+	return p
+}
+
+// Start starts the workers, handling updates with ctx.
+func (p *WorkerPool) Start(ctx context.Context) {
+	for _, queue := range p.queues {
+		p.wg.Add(1)
+		go p.work(ctx, queue)
+	}
+}
+
+func (p *WorkerPool) work(ctx context.Context, queue chan Update) {
+	defer p.wg.Done()
+	for update := range queue {
+		update := update
+		if err := p.handler.HandleUpdate(ctx, &update); err != nil && p.onError != nil {
+			p.onError(ctx, &update, err)
+		}
+	}
+}
+
+// Submit queues an update, blocking while the queue of its worker is full.
+func (p *WorkerPool) Submit(ctx context.Context, update Update) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrWorkerPoolClosed
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+	defer p.senders.Done()
+
+	queue := p.queues[uint(UpdateOrderingKey(&update))%uint(len(p.queues))]
+	select {
+	case queue <- update:
+		return nil
+	case <-p.done:
+		return ErrWorkerPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops accepting updates and waits until the queued updates are
+// handled or ctx is done. Blocked calls to Submit return ErrWorkerPoolClosed.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	p.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		p.senders.Wait()
+		p.closeOnce.Do(func() {
+			for _, queue := range p.queues {
+				close(queue)
+			}
+		})
+		p.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// UpdateOrderingKey returns the key of the updates that must be handled in
+// order with update: the chat id, or the user id for inline queries, chosen
+// inline results, callback queries and poll answers.
+func UpdateOrderingKey(update *Update) int {
+	switch {
+	case update.InlineQuery != nil, update.ChosenInlineResult != nil, update.CallbackQuery != nil, update.PollAnswer != nil:
+		if user := update.EffectiveUser(); user != nil {
+			return user.Id
+		}
+	case update.EffectiveChat() != nil:
+		return update.EffectiveChat().Id
+	case update.EffectiveUser() != nil:
+		return update.EffectiveUser().Id
+	}
+	return update.UpdateId
+}
@@ -0,0 +1,79 @@
+package telegram
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool_Order(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[int][]int)
+
+	pool := NewWorkerPool(HandlerFunc(func(ctx context.Context, update *Update) error {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		mu.Lock()
+		defer mu.Unlock()
+		chatId := update.Message.Chat.Id
+		handled[chatId] = append(handled[chatId], update.UpdateId)
+		return nil
+	}), WorkerPoolOptions{Workers: 4, QueueSize: 2})
+	pool.Start(context.Background())
+
+	for i := 0; i < 200; i++ {
+		update := Update{UpdateId: i, Message: &Message{Chat: &Chat{Id: i % 7}}}
+		if err := pool.Submit(context.Background(), update); err != nil {
+			t.Fatalf("Submit returned error %v", err)
+		}
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error %v", err)
+	}
+	if err := pool.Submit(context.Background(), Update{}); err != ErrWorkerPoolClosed {
+		t.Errorf("Submit after Shutdown err is %v; want %v", err, ErrWorkerPoolClosed)
+	}
+
+	total := 0
+	for chatId, ids := range handled {
+		total += len(ids)
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Errorf("chat %d handled update %d after %d", chatId, ids[i], ids[i-1])
+			}
+		}
+	}
+	if total != 200 {
+		t.Errorf("handled %d updates; want 200", total)
+	}
+}
+
+func TestWorkerPool_ShutdownDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	p := NewWorkerPool(HandlerFunc(func(ctx context.Context, update *Update) error {
+		<-release
+		return nil
+	}), WorkerPoolOptions{Workers: 1, QueueSize: 1})
+	p.Start(context.Background())
+
+	// One update is being handled, one is queued, the third blocks.
+	p.Submit(context.Background(), Update{UpdateId: 1})
+	p.Submit(context.Background(), Update{UpdateId: 2})
+	submitted := make(chan error)
+	go func() {
+		submitted <- p.Submit(context.Background(), Update{UpdateId: 3})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown err is %v; want %v", err, context.DeadlineExceeded)
+	}
+	if err := <-submitted; err != ErrWorkerPoolClosed {
+		t.Errorf("blocked Submit err is %v; want %v", err, ErrWorkerPoolClosed)
+	}
+}